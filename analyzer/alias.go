@@ -0,0 +1,86 @@
+package analyzer
+
+import (
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+
+	"dev.gaijin.team/go/exhaustruct/v4/internal/pattern"
+)
+
+// AliasMatching defines which name of a structure, referred by a type alias at
+// literal site, is matched by type patterns.
+type AliasMatching string
+
+const (
+	// AliasMatchingTarget matches the name of aliased type only.
+	AliasMatchingTarget AliasMatching = "target"
+
+	// AliasMatchingAlias matches the name of alias only.
+	AliasMatchingAlias AliasMatching = "alias"
+
+	// AliasMatchingBoth matches both names, pattern matching any of them
+	// applies.
+	AliasMatchingBoth AliasMatching = "both"
+)
+
+// ParseAliasMatching converts a string into [AliasMatching]. Empty string is
+// treated as [AliasMatchingTarget].
+func ParseAliasMatching(s string) (AliasMatching, error) {
+	switch m := AliasMatching(s); m {
+	case "":
+		return AliasMatchingTarget, nil
+	case AliasMatchingTarget, AliasMatchingAlias, AliasMatchingBoth:
+		return m, nil
+	default:
+		return "", e.New("unknown alias matching mode", fields.F("alias-matching", s))
+	}
+}
+
+// Set implements [flag.Value] interface.
+func (m *AliasMatching) Set(value string) error {
+	v, err := ParseAliasMatching(value)
+	if err != nil {
+		return err
+	}
+
+	*m = v
+
+	return nil
+}
+
+// String implements [flag.Value] interface.
+func (m *AliasMatching) String() string {
+	if m == nil {
+		return ""
+	}
+
+	return string(*m)
+}
+
+// typeNames returns full names of a type, that should be matched by type
+// patterns.
+func (c *Config) typeNames(info *TypeInfo) []string {
+	if info.Alias == nil {
+		return info.names()
+	}
+
+	switch c.AliasMatching {
+	case AliasMatchingAlias:
+		return info.Alias.names()
+	case AliasMatchingBoth:
+		return append(info.names(), info.Alias.names()...)
+	default:
+		return info.names()
+	}
+}
+
+// matchAny checks whether any of given names matches the pattern list.
+func matchAny(l pattern.List, names []string) bool {
+	for _, name := range names {
+		if l.MatchFullString(name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,10 +4,15 @@ import (
 	"flag"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/token"
 	"go/types"
+	"reflect"
+	"strings"
 	"sync"
 
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
@@ -16,47 +21,270 @@ import (
 	"dev.gaijin.team/go/exhaustruct/v4/internal/structure"
 )
 
+// defaultName is the default name of analyzer, used in diagnostics and
+// suppressions.
+const defaultName = "exhaustruct"
+
 type analyzer struct {
+	name   string
 	config Config
 
-	structFields structure.FieldsCache `exhaustruct:"optional"`
-	comments     comment.Cache         `exhaustruct:"optional"`
+	// prepareOnce guards compilation of configuration, which is postponed
+	// until the first run, so values set through flags after analyzer
+	// creation are taken into account.
+	prepareOnce sync.Once `exhaustruct:"optional"`
+	prepareErr  error     `exhaustruct:"optional"`
+
+	structFields *structure.FieldsCache
+	comments     comment.Cache `exhaustruct:"optional"`
+	moduleRoots  moduleRoots   `exhaustruct:"optional"`
 
 	typeProcessingNeed   map[string]bool
 	typeProcessingNeedMu sync.RWMutex `exhaustruct:"optional"`
 }
 
-func NewAnalyzer(config Config) (*analysis.Analyzer, error) {
+// Option customizes an analyzer instance created by [NewAnalyzer].
+type Option func(*options)
+
+type options struct {
+	name string
+	doc  string
+}
+
+// WithName sets the name of analyzer instance, which must be a valid Go
+// identifier. Distinct names allow to run several instances with different
+// configuration side by side, e.g. within a multichecker, where flags of each
+// instance are prefixed with its name.
+//
+// Suppression comments (see [Config.HonorNolint]) are recognized for both
+// the instance name and the default "exhaustruct" name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithDoc sets the documentation of analyzer instance.
+func WithDoc(doc string) Option {
+	return func(o *options) {
+		o.doc = doc
+	}
+}
+
+// NewAnalyzer creates an analyzer with a given configuration. Configuration is
+// validated right away, but it is compiled and frozen only at the start of the
+// first run, so it can be adjusted through [analysis.Analyzer.Flags] until
+// then.
+func NewAnalyzer(config Config, opts ...Option) (*analysis.Analyzer, error) {
+	o := options{
+		name: defaultName,
+		doc:  "Checks if all structure fields are initialized",
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if !token.IsIdentifier(o.name) {
+		return nil, e.New("analyzer name must be a valid identifier", fields.F("name", o.name))
+	}
+
 	err := config.Prepare()
 	if err != nil {
 		return nil, err
 	}
 
-	a := analyzer{
+	a := newAnalyzer(o.name, config)
+
+	res := &analysis.Analyzer{ //nolint:exhaustruct
+		Name:       o.name,
+		Doc:        o.doc,
+		Run:        a.run,
+		Requires:   a.requires(),
+		Flags:      *a.config.BindToFlagSet(flag.NewFlagSet(o.name, flag.PanicOnError)),
+		ResultType: reflect.TypeOf((*Result)(nil)),
+	}
+
+	// features relying on facts may be enabled by flags, drivers read required
+	// analyzers only after flags are parsed
+	res.Flags.VisitAll(func(f *flag.Flag) {
+		f.Value = updatingFlag{Value: f.Value, update: func() { res.Requires = a.requires() }}
+	})
+
+	return res, nil
+}
+
+// requires returns a list of analyzers required by the analyzer under its
+// current configuration. [factsAnalyzer] is only required in case features
+// relying on facts are enabled, otherwise drivers would have to run it over
+// all dependencies.
+func (a *analyzer) requires() []*analysis.Analyzer {
+	req := []*analysis.Analyzer{inspect.Analyzer}
+
+	if cfg, err := a.config.withProfile(); err == nil && cfg.needsFacts() {
+		req = append(req, factsAnalyzer)
+	}
+
+	return req
+}
+
+// updatingFlag is a flag value calling a given function after every change.
+type updatingFlag struct {
+	flag.Value
+
+	update func()
+}
+
+func (f updatingFlag) Set(value string) error {
+	if err := f.Value.Set(value); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	f.update()
+
+	return nil
+}
+
+func (f updatingFlag) IsBoolFlag() bool {
+	bf, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && bf.IsBoolFlag()
+}
+
+func newAnalyzer(name string, config Config) *analyzer {
+	return &analyzer{
+		name:               name,
 		config:             config,
+		structFields:       structure.NewFieldsCache(config.fieldTags),
 		typeProcessingNeed: make(map[string]bool),
 		comments:           comment.Cache{},
 	}
+}
+
+// Result is a result of analyzer run over a single package. Along with every
+// reported diagnostic it holds data that can not be expressed by
+// [analysis.Diagnostic], e.g. severity. It is meant to be consumed by drivers
+// that are able to access analyzer results, like cmd/exhaustruct.
+type Result struct {
+	Findings []Finding
+}
+
+// Finding is a single reported issue.
+type Finding struct {
+	Diagnostic analysis.Diagnostic
+	Rule       Rule
+	Severity   Severity
+}
+
+// newFinding creates a finding of a given rule and severity.
+func newFinding(pos token.Pos, rule Rule, sev Severity, format string, args ...any) Finding {
+	return Finding{
+		Diagnostic: newDiagnostic(pos, rule, format, args...),
+		Rule:       rule,
+		Severity:   sev,
+	}
+}
+
+// report reports finding's diagnostic and records the finding.
+func (r *Result) report(pass *analysis.Pass, f Finding) {
+	pass.Report(f.Diagnostic)
+
+	r.Findings = append(r.Findings, f)
+}
+
+// prepare applies selected profile and compiles analyzer configuration once,
+// returning the same error on every call in case configuration is invalid.
+func (a *analyzer) prepare() error {
+	a.prepareOnce.Do(func() {
+		cfg, err := a.config.withProfile()
+		if err != nil {
+			a.prepareErr = err
+			return
+		}
+
+		if err = cfg.Prepare(); err != nil {
+			a.prepareErr = err
+			return
+		}
+
+		a.config = cfg
+		a.structFields = structure.NewFieldsCache(cfg.fieldTags)
+	})
 
-	return &analysis.Analyzer{ //nolint:exhaustruct
-		Name:     "exhaustruct",
-		Doc:      "Checks if all structure fields are initialized",
-		Run:      a.run,
-		Requires: []*analysis.Analyzer{inspect.Analyzer},
-		Flags:    *a.config.BindToFlagSet(flag.NewFlagSet("", flag.PanicOnError)),
-	}, nil
+	return a.prepareErr
 }
 
 func (a *analyzer) run(pass *analysis.Pass) (any, error) {
+	if err := a.prepare(); err != nil {
+		return nil, e.NewFrom("invalid configuration", err)
+	}
+
+	res := &Result{Findings: nil}
+
+	a.forPackage(pass, res).check(pass, res)
+
+	return res, nil
+}
+
+// check checks all composite literals of the package along with comment
+// directives.
+func (a *analyzer) check(pass *analysis.Pass, res *Result) {
 	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector) //nolint:forcetypeassert
+	generated := a.generatedFiles(pass)
 
-	insp.WithStack([]ast.Node{(*ast.CompositeLit)(nil)}, a.newVisitor(pass))
+	a.checkConstructorDirectives(pass, res)
+
+	insp.WithStack([]ast.Node{(*ast.CompositeLit)(nil)}, a.newVisitor(pass, res, generated))
+
+	for _, f := range pass.Files {
+		if generated[f] {
+			continue
+		}
 
-	return nil, nil //nolint:nilnil
+		for _, c := range comment.InvalidDirectives(f) {
+			res.report(pass, newFinding(c.Pos(), RuleInvalidDirective, a.config.defaultSeverity(),
+				"unknown directive %s", c.Text))
+		}
+	}
+}
+
+// newDiagnostic creates a diagnostic of a given rule.
+func newDiagnostic(pos token.Pos, rule Rule, format string, args ...any) analysis.Diagnostic {
+	return analysis.Diagnostic{ //nolint:exhaustruct
+		Pos:      pos,
+		Category: rule.Code,
+		URL:      rule.URL(),
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// generatedFiles returns a set of pass files that should be treated as
+// generated. In case generated files checking is enabled, the set is empty.
+func (a *analyzer) generatedFiles(pass *analysis.Pass) map[*ast.File]bool {
+	generated := make(map[*ast.File]bool)
+
+	if a.config.CheckGenerated {
+		return generated
+	}
+
+	for _, f := range pass.Files {
+		if ast.IsGenerated(f) {
+			generated[f] = true
+		}
+	}
+
+	return generated
 }
 
 // newVisitor returns visitor that only expects [ast.CompositeLit] nodes.
-func (a *analyzer) newVisitor(pass *analysis.Pass) func(n ast.Node, push bool, stack []ast.Node) bool {
+func (a *analyzer) newVisitor(
+	pass *analysis.Pass,
+	res *Result,
+	generated map[*ast.File]bool,
+) func(n ast.Node, push bool, stack []ast.Node) bool {
+	// checked literals, nested literals of which are checked as their parts in
+	// deep mode
+	checked := make(map[*ast.CompositeLit]deepLiteral)
+
 	return func(n ast.Node, push bool, stack []ast.Node) bool {
 		if !push {
 			return true
@@ -68,124 +296,145 @@ func (a *analyzer) newVisitor(pass *analysis.Pass) func(n ast.Node, push bool, s
 			return true
 		}
 
-		structTyp, typeInfo, ok := getStructType(pass, lit)
+		structTyp, typeInfo, ok := getStructType(pass, stack)
 		if !ok {
 			return true
 		}
 
-		if len(lit.Elts) == 0 && a.checkEmptyStructAllowed(pass, stack, typeInfo) {
-			return true
+		site := a.getLiteralSite(pass, lit)
+
+		// nested literals of checked ones are always checked in deep mode
+		deep, enforced := getDeepParent(pass, stack, checked)
+		if !enforced {
+			// literals in generated files are only checked for explicitly included types
+			if generated[stack[0].(*ast.File)] && //nolint:forcetypeassert
+				!matchAny(a.config.generatedIncludePatterns, a.config.typeNames(typeInfo)) {
+				return true
+			}
+
+			// literals of constructor-only types are reported regardless of
+			// allowances, as neither empty nor partial literal is valid for them
+			var skip bool
+			if enforced, skip = a.checkLiteralPolicies(pass, stack, lit, site, typeInfo); skip &&
+				!a.isConstructorOnly(pass, lit) {
+				return true
+			}
 		}
 
 		file := a.comments.Get(pass.Fset, stack[0].(*ast.File)) //nolint:forcetypeassert
-		rc := getCompositeLitRelatedComments(stack, file)
-		pos, msg := a.processStruct(pass, lit, structTyp, typeInfo, rc)
-
-		if pos != nil {
-			pass.Reportf(*pos, "%s", msg)
+		lcm := literalComments{
+			related: getCompositeLitRelatedComments(stack, file),
+			own:     getCompositeLitOwnComments(stack, file),
 		}
 
-		return true
-	}
-}
+		if !a.shouldCheckLiteral(pass, site, typeInfo, lcm, enforced) {
+			return true
+		}
 
-func (a *analyzer) checkEmptyStructAllowed(pass *analysis.Pass, stack []ast.Node, typeInfo *TypeInfo) bool {
-	// empty structs are globally allowed
-	if a.config.AllowEmpty {
-		return true
-	}
+		// path is only set for nested literals
+		path := deep.path
 
-	// some structs are allowed to be empty, basing on pattern
-	if a.config.allowEmptyPatterns.MatchFullString(typeInfo.String()) {
-		return true
-	}
+		if a.config.Deep {
+			if path == "" {
+				deep = deepLiteral{path: typeInfo.ShortString()}
+			}
 
-	if ret, ok := getParentReturnStmt(stack); ok {
-		// empty structures are allowed in all return statements
-		if a.config.AllowEmptyReturns {
-			return true
+			checked[lit] = deep
 		}
 
-		// empty structures are allowed in error returns
-		if isErrorReturnStatement(pass, ret, stack[len(stack)-1]) {
-			return true
+		for _, f := range a.processStruct(pass, lit, structTyp, typeInfo, lcm, path) {
+			res.report(pass, f)
 		}
-	}
 
-	// empty structures are allowed in variable declarations
-	if isChildOfVariableDeclaration(stack) && a.config.AllowEmptyDeclarations {
 		return true
 	}
-
-	return false
 }
 
-// isPartOfVariableDeclaration checks if the node is direct part of variable
-// declaration, meaning that it is a first-level RHS child of `:=` or `var`
-// declaration.
-func isChildOfVariableDeclaration(stack []ast.Node) bool {
-	if len(stack) < 2 { //nolint:mnd // stack for sure contains at leas current node and its parent (file)
-		return false
+// checkLiteralPolicies checks whether a literal should be skipped basing on
+// callee, site and context policies. In case the literal is passed to a callee
+// with strict policy, it is enforced to be checked.
+func (a *analyzer) checkLiteralPolicies(
+	pass *analysis.Pass,
+	stack []ast.Node,
+	lit *ast.CompositeLit,
+	site literalSite,
+	typeInfo *TypeInfo,
+) (enforced, skip bool) {
+	lc := getLiteralContext(pass.TypesInfo, stack)
+	calleePolicy, hasCalleePolicy := a.getCalleePolicy(pass, stack, lc)
+	enforced = hasCalleePolicy && calleePolicy == PolicyStrict
+
+	switch {
+	case enforced:
+		// structures passed to such callees are always checked
+		return true, false
+	case hasCalleePolicy && (calleePolicy.allowsPartial() || len(lit.Elts) == 0 && calleePolicy.allowsEmpty()):
+		return false, true
 	}
 
-	// Start from composite literal and go up the stack
-	for i := len(stack) - 1; i > 0; i-- {
-		parent := stack[i-1]
-
-		switch p := parent.(type) {
-		case *ast.AssignStmt:
-			if p.Tok == token.DEFINE {
-				return true
-			}
+	// site rules have precedence over other allowances
+	if p, ok := a.getSitePolicy(site, a.config.typeNames(typeInfo)); ok {
+		return false, p.allowsPartial() || len(lit.Elts) == 0 && p.allowsEmpty()
+	}
 
-		case *ast.ValueSpec:
-			return true
+	return false, a.isIncompleteStructAllowed(pass, stack, lc, lit, typeInfo)
+}
 
-		case *ast.UnaryExpr:
-			// Only allow pointer taking (&)
-			if p.Op == token.AND {
-				continue
-			}
+// isIncompleteStructAllowed checks whether the literal is allowed to be
+// incomplete (empty or partially initialized) basing on configuration and
+// the context it is used in.
+func (a *analyzer) isIncompleteStructAllowed(
+	pass *analysis.Pass,
+	stack []ast.Node,
+	lc literalContext,
+	lit *ast.CompositeLit,
+	typeInfo *TypeInfo,
+) bool {
+	// empty and partially initialized structures are allowed in failure returns
+	if ret, ok := lc.Node.(*ast.ReturnStmt); ok && a.isFailureReturnStatement(pass, stack, ret, lit) {
+		return true
+	}
 
-			return false
+	// same applies to structures that are only returned on failure through local variable
+	if a.isReturnedOnlyOnFailure(pass, stack, lc, len(lit.Elts) == 0) {
+		return true
+	}
 
-		default:
-			return false
-		}
+	if len(lit.Elts) == 0 {
+		return a.checkEmptyStructAllowed(pass, lc, lit, typeInfo)
 	}
 
-	return false
+	// partially initialized structures are allowed in configured contexts
+	return a.config.contextPolicy(lc.Kind).allowsPartial()
 }
 
-// getParentReturnStmt checks if the direct parent of the current node is a
-// return statement and returns it if so.
-func getParentReturnStmt(stack []ast.Node) (*ast.ReturnStmt, bool) {
-	if len(stack) < 2 { //nolint:mnd // stack for sure contains at leas current node and its parent (file)
-		return nil, false
+func (a *analyzer) checkEmptyStructAllowed(
+	pass *analysis.Pass,
+	lc literalContext,
+	lit *ast.CompositeLit,
+	typeInfo *TypeInfo,
+) bool {
+	// empty structs are globally allowed
+	if a.config.AllowEmpty {
+		return true
 	}
 
-	// Start from composite literal and go up the stack
-	for i := len(stack) - 1; i > 0; i-- {
-		parent := stack[i-1]
-
-		switch p := parent.(type) {
-		case *ast.ReturnStmt:
-			return p, true
-
-		case *ast.UnaryExpr:
-			// Only allow pointer taking (&)
-			if p.Op == token.AND {
-				continue
-			}
+	// some structs are allowed to be empty, basing on pattern
+	if matchAny(a.config.allowEmptyPatterns, a.config.typeNames(typeInfo)) {
+		return true
+	}
 
-			return nil, false
+	// empty structures are allowed in configured contexts
+	if a.config.contextPolicy(lc.Kind).allowsEmpty() {
+		return true
+	}
 
-		default:
-			return nil, false
-		}
+	// empty structures are allowed in error returns
+	if ret, ok := lc.Node.(*ast.ReturnStmt); ok && isErrorReturnStatement(pass, ret, lit) {
+		return true
 	}
 
-	return nil, false
+	return false
 }
 
 // errorIface is an interface type of the [error] interface.
@@ -233,6 +482,101 @@ func isErrorReturnStatement(pass *analysis.Pass, n *ast.ReturnStmt, currentNode
 	return false
 }
 
+// isFailureReturnStatement checks if the return statement is a failure return
+// statement, meaning that either the enclosing function is a comma-ok one (its
+// last result is a boolean) and constant `false` is returned as last result, or
+// statement contains a non-nil value of a configured failure type.
+func (a *analyzer) isFailureReturnStatement(
+	pass *analysis.Pass,
+	stack []ast.Node,
+	n *ast.ReturnStmt,
+	currentNode ast.Node,
+) bool {
+	if len(n.Results) == 0 {
+		return false
+	}
+
+	if sig, ok := getEnclosingFuncSignature(pass, stack); ok && sig.Results().Len() == len(n.Results) {
+		last := n.Results[len(n.Results)-1]
+		tv := pass.TypesInfo.Types[last]
+
+		if isBoolType(sig.Results().At(sig.Results().Len()-1).Type()) &&
+			tv.Value != nil && tv.Value.Kind() == constant.Bool && !constant.BoolVal(tv.Value) {
+			return true
+		}
+	}
+
+	if len(a.config.failurePatterns) == 0 {
+		return false
+	}
+
+	for _, ri := range n.Results {
+		if ri == currentNode || isNilIdent(ri) {
+			continue
+		}
+
+		if u, ok := ri.(*ast.UnaryExpr); ok && u.X == currentNode {
+			continue
+		}
+
+		name, ok := namedTypeString(pass.TypesInfo.TypeOf(ri))
+		if ok && a.config.failurePatterns.MatchFullString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getEnclosingFuncSignature returns a signature of the innermost function
+// (declaration or literal) the top of the stack belongs to.
+func getEnclosingFuncSignature(pass *analysis.Pass, stack []ast.Node) (*types.Signature, bool) {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncLit:
+			sig, ok := pass.TypesInfo.TypeOf(fn).(*types.Signature)
+			return sig, ok
+
+		case *ast.FuncDecl:
+			obj := pass.TypesInfo.Defs[fn.Name]
+			if obj == nil {
+				return nil, false
+			}
+
+			sig, ok := obj.Type().(*types.Signature)
+
+			return sig, ok
+		}
+	}
+
+	return nil, false
+}
+
+func isBoolType(typ types.Type) bool {
+	b, ok := typ.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsBoolean != 0
+}
+
+func isNilIdent(n ast.Expr) bool {
+	ident, ok := n.(*ast.Ident)
+	return ok && ident.Name == "nil"
+}
+
+// namedTypeString returns the full name of a named type, including package
+// path. Pointers are dereferenced.
+func namedTypeString(typ types.Type) (string, bool) {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return "", false
+	}
+
+	return named.Obj().Pkg().Path() + "." + named.Obj().Name(), true
+}
+
 // getCompositeLitRelatedComments returns all comments that are related to checked node. We
 // have to traverse the stack manually as ast do not associate comments with
 // [ast.CompositeLit].
@@ -269,68 +613,171 @@ func getCompositeLitRelatedComments(stack []ast.Node, cm ast.CommentMap) []*ast.
 	return comments
 }
 
-func getStructType(pass *analysis.Pass, lit *ast.CompositeLit) (*types.Struct, *TypeInfo, bool) {
-	switch typ := types.Unalias(pass.TypesInfo.TypeOf(lit)).(type) {
-	case *types.Named: // named type
-		if structTyp, ok := typ.Underlying().(*types.Struct); ok {
-			pkg := typ.Obj().Pkg()
-			ti := TypeInfo{
-				Name:        typ.Obj().Name(),
-				PackageName: pkg.Name(),
-				PackagePath: pkg.Path(),
-			}
-
-			return structTyp, &ti, true
+// getCompositeLitOwnComments returns comments that are related to checked node,
+// same as [getCompositeLitRelatedComments] does, except of comments related to
+// enclosing composite literals.
+func getCompositeLitOwnComments(stack []ast.Node, cm ast.CommentMap) []*ast.CommentGroup {
+	for i := len(stack) - 2; i >= 0; i-- {
+		if _, ok := stack[i].(*ast.CompositeLit); ok {
+			return getCompositeLitRelatedComments(stack[i+1:], cm)
 		}
+	}
 
-		return nil, nil, false
+	return getCompositeLitRelatedComments(stack, cm)
+}
 
-	case *types.Struct: // anonymous struct
-		ti := TypeInfo{
-			Name:        "<anonymous>",
-			PackageName: pass.Pkg.Name(),
-			PackagePath: pass.Pkg.Path(),
-		}
+// literalComments holds comments related to a composite literal.
+type literalComments struct {
+	// related are comments of the literal and its parents, including enclosing
+	// literals.
+	related []*ast.CommentGroup
+
+	// own are comments of the literal and its parents up to enclosing literal.
+	own []*ast.CommentGroup
+}
+
+// shouldCheckLiteral checks whether a literal of a given type should be
+// checked basing on scope, site and type patterns, suppression comments and
+// directives.
+func (a *analyzer) shouldCheckLiteral(
+	pass *analysis.Pass,
+	site literalSite,
+	info *TypeInfo,
+	comments literalComments,
+	enforced bool,
+) bool {
+	if a.config.HonorNolint && (comment.HasNolint(comments.related, defaultName) ||
+		comment.HasNolint(comments.related, a.name)) {
+		return false
+	}
 
-		return typ, &ti, true
+	shouldProcess := enforced || a.inScope(pass, info) && a.isSiteIncluded(site) && a.shouldProcessType(info)
 
-	default:
-		return nil, nil, false
+	if shouldProcess && comment.HasDirective(comments.related, comment.DirectiveIgnore) {
+		return false
 	}
+
+	return shouldProcess || comment.HasDirective(comments.related, comment.DirectiveEnforce)
 }
 
+// processStruct checks a literal of a given structure type. In case path is
+// not empty, literal is a nested one, checked in deep mode, and missing fields
+// are reported by path.
 func (a *analyzer) processStruct(
 	pass *analysis.Pass,
 	lit *ast.CompositeLit,
 	structTyp *types.Struct,
 	info *TypeInfo,
-	comments []*ast.CommentGroup,
-) (*token.Pos, string) {
-	shouldProcess := a.shouldProcessType(info)
-
-	if shouldProcess && comment.HasDirective(comments, comment.DirectiveIgnore) {
-		return nil, ""
-	}
-
-	if !shouldProcess && !comment.HasDirective(comments, comment.DirectiveEnforce) {
-		return nil, ""
+	comments literalComments,
+	path string,
+) []Finding {
+	sev := a.config.severityOf(a.config.typeNames(info)...)
+
+	if ctor, ok := a.getConstructor(pass, lit); ok {
+		return []Finding{newFinding(lit.Pos(), RuleConstructorOnly, sev,
+			"%s must be created with constructor %s.%s%s", info.ShortString(), info.PackageName, ctor, info.aliasNote())}
 	}
 
 	// unnamed structures are only defined in same package, along with types that has
 	// prefix identical to current package name.
 	isSamePackage := info.PackagePath == pass.Pkg.Path()
 
-	if f := a.litSkippedFields(lit, structTyp, !isSamePackage); len(f) > 0 {
-		pos := lit.Pos()
+	f := a.filterRequired(pass, structTyp, a.litSkippedFields(lit, structTyp, !isSamePackage))
+	f, findings := a.applySkipDirectives(structTyp, info, comments.own, f, sev)
+	findings = append(findings, a.checkForbiddenFields(pass, lit, structTyp, info, sev)...)
+	findings = append(findings, a.checkNonZeroFields(pass, lit, structTyp, info, sev)...)
+
+	if len(f) == 0 {
+		return findings
+	}
+
+	if t, ok := a.config.thresholdOf(a.config.typeNames(info)...); ok {
+		required := a.filterRequired(pass, structTyp, a.structFields.Get(structTyp).Required(!isSamePackage))
+		if t.allows(len(f), len(required), len(lit.Elts) == 0) {
+			return findings
+		}
+	}
+
+	if path != "" {
+		return append(findings, newFinding(lit.Pos(), literalRule(lit), sev,
+			"%s%s", deepMissingMessage(path, f), info.aliasNote()))
+	}
+
+	return append(findings, newFinding(lit.Pos(), literalRule(lit), sev,
+		"%s is missing %s%s", info.ShortString(), missingFieldsMessage(structTyp, f), info.aliasNote()))
+}
+
+// applySkipDirectives removes fields listed in `//exhaustruct:skip` directives
+// from a list of missing fields. Directives naming fields that do not exist in
+// the structure are reported.
+func (a *analyzer) applySkipDirectives(
+	structTyp *types.Struct,
+	info *TypeInfo,
+	comments []*ast.CommentGroup,
+	missing structure.Fields,
+	sev Severity,
+) (structure.Fields, []Finding) {
+	directives := comment.FindDirectives(comments, comment.DirectiveSkip)
+	if len(directives) == 0 {
+		return missing, nil
+	}
+
+	var findings []Finding
 
-		if len(f) == 1 {
-			return &pos, fmt.Sprintf("%s is missing field %s", info.ShortString(), f.String())
+	all := a.structFields.Get(structTyp)
+	skipped := make(map[string]bool)
+
+	for _, d := range directives {
+		args := comment.DirectiveArgs(d)
+		if len(args) == 0 {
+			findings = append(findings, newFinding(d.Pos(), RuleInvalidDirective, sev,
+				"%s directive requires a comma-separated list of fields", comment.DirectiveSkip))
+
+			continue
+		}
+
+		for _, name := range strings.Split(args[0], ",") {
+			if all.Has(name) {
+				skipped[name] = true
+				continue
+			}
+
+			findings = append(findings, newFinding(d.Pos(), RuleInvalidDirective, sev,
+				"%s directive names unknown field %s of %s", comment.DirectiveSkip, name, info.ShortString()))
 		}
+	}
+
+	res := make(structure.Fields, 0, len(missing))
+
+	for _, f := range missing {
+		if !skipped[f.Name] {
+			res = append(res, f)
+		}
+	}
+
+	return res, findings
+}
+
+// literalRule returns the rule that is violated by a literal with missing
+// fields.
+func literalRule(lit *ast.CompositeLit) Rule {
+	if len(lit.Elts) == 0 {
+		return RuleEmptyLiteral
+	}
+
+	if _, ok := lit.Elts[0].(*ast.KeyValueExpr); !ok {
+		return RuleUnkeyedLiteral
+	}
+
+	return RuleMissingFields
+}
 
-		return &pos, fmt.Sprintf("%s is missing fields %s", info.ShortString(), f.String())
+func pluralizeField(n int) string {
+	if n == 1 {
+		return "field"
 	}
 
-	return nil, ""
+	return "fields"
 }
 
 // shouldProcessType returns true if type should be processed basing off include
@@ -340,10 +787,11 @@ func (a *analyzer) shouldProcessType(info *TypeInfo) bool {
 		return true
 	}
 
-	name := info.String()
+	names := a.config.typeNames(info)
+	key := strings.Join(names, " ")
 
 	a.typeProcessingNeedMu.RLock()
-	res, ok := a.typeProcessingNeed[name]
+	res, ok := a.typeProcessingNeed[key]
 	a.typeProcessingNeedMu.RUnlock()
 
 	if !ok {
@@ -351,15 +799,15 @@ func (a *analyzer) shouldProcessType(info *TypeInfo) bool {
 
 		res = true
 
-		if a.config.includePatterns != nil && !a.config.includePatterns.MatchFullString(name) {
+		if a.config.includePatterns != nil && !matchAny(a.config.includePatterns, names) {
 			res = false
 		}
 
-		if res && a.config.excludePatterns != nil && a.config.excludePatterns.MatchFullString(name) {
+		if res && a.config.excludePatterns != nil && matchAny(a.config.excludePatterns, names) {
 			res = false
 		}
 
-		a.typeProcessingNeed[name] = res
+		a.typeProcessingNeed[key] = res
 		a.typeProcessingNeedMu.Unlock()
 	}
 
@@ -378,6 +826,20 @@ type TypeInfo struct {
 	Name        string
 	PackageName string
 	PackagePath string
+
+	// Alias is a type alias the structure is referred by at literal site, if
+	// any.
+	Alias *TypeInfo `exhaustruct:"optional"`
+
+	// pos is a position of type declaration, used to find the module type
+	// belongs to. Anonymous structures share it with the structure they are a
+	// field value of, if any, otherwise it is not set.
+	pos token.Pos `exhaustruct:"optional"`
+
+	// legacyName is a full name anonymous and function-local structures had
+	// before being qualified, e.g. `pkg.<anonymous>`. It is matched by type
+	// patterns along with the full name, so existing patterns keep working.
+	legacyName string `exhaustruct:"optional"`
 }
 
 func (t TypeInfo) String() string {
@@ -387,3 +849,22 @@ func (t TypeInfo) String() string {
 func (t TypeInfo) ShortString() string {
 	return t.PackageName + "." + t.Name
 }
+
+// names returns full names of the type matched by type patterns.
+func (t TypeInfo) names() []string {
+	if t.legacyName == "" || t.legacyName == t.String() {
+		return []string{t.String()}
+	}
+
+	return []string{t.String(), t.legacyName}
+}
+
+// aliasNote returns a note on type alias the type is referred by, to be
+// appended to diagnostics. Empty string is returned if there is no alias.
+func (t TypeInfo) aliasNote() string {
+	if t.Alias == nil {
+		return ""
+	}
+
+	return " (via alias " + t.Alias.ShortString() + ")"
+}
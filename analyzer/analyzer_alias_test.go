@@ -0,0 +1,50 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestAnalyzerAliasMatching(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		mode        analyzer.AliasMatching
+		testPackage string
+	}{
+		{
+			name:        "aliased type name is matched by default",
+			mode:        "",
+			testPackage: "aliases_target",
+		},
+		{
+			name:        "alias name is matched",
+			mode:        analyzer.AliasMatchingAlias,
+			testPackage: "aliases_alias",
+		},
+		{
+			name:        "both names are matched",
+			mode:        analyzer.AliasMatchingBoth,
+			testPackage: "aliases_both",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := analyzer.NewAnalyzer(analyzer.Config{
+				ExcludeRx:     []string{`.*\.Opts`, `.*\.Hidden`},
+				AliasMatching: tt.mode,
+			})
+			require.NoError(t, err)
+
+			analysistest.Run(t, testdataPath, a, tt.testPackage)
+		})
+	}
+}
@@ -23,3 +23,25 @@ func BenchmarkAnalyzer(b *testing.B) {
 		_ = analysistest.Run(b, testdataPath, a, "i")
 	}
 }
+
+func BenchmarkAnalyzer_Facts(b *testing.B) {
+	for _, bb := range []struct {
+		name   string
+		config analyzer.Config
+	}{
+		{name: "disabled", config: analyzer.Config{}},
+		{name: "enabled", config: analyzer.Config{CheckConstructors: true, CheckDeprecated: true}},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			a, err := analyzer.NewAnalyzer(bb.config)
+			require.NoError(b, err)
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = analysistest.Run(b, testdataPath, a, "facts")
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestAnalyzerConstructors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		config       analyzer.Config
+		testPackages []string
+	}{
+		{
+			name:         "constructors are detected",
+			config:       analyzer.Config{CheckConstructors: true, DetectConstructors: true},
+			testPackages: []string{"ctor", "ctor_use"},
+		},
+		{
+			name:         "only annotated constructors",
+			config:       analyzer.Config{CheckConstructors: true},
+			testPackages: []string{"ctor_directive_only"},
+		},
+		{
+			name:         "constructors are checked regardless of allowances",
+			config:       analyzer.Config{CheckConstructors: true, AllowEmpty: true},
+			testPackages: []string{"ctor_allowances"},
+		},
+		{
+			name:         "constructors are not checked",
+			config:       analyzer.Config{},
+			testPackages: []string{"ctor_unchecked"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := analyzer.NewAnalyzer(tt.config)
+			require.NoError(t, err)
+
+			analysistest.Run(t, testdataPath, a, tt.testPackages...)
+		})
+	}
+}
+
+func TestNewAnalyzer_RequiresFacts(t *testing.T) {
+	t.Parallel()
+
+	requires := func(a *analysis.Analyzer) []string {
+		names := make([]string, 0, len(a.Requires))
+		for _, r := range a.Requires {
+			names = append(names, r.Name)
+		}
+
+		return names
+	}
+
+	t.Run("facts are not required by default", func(t *testing.T) {
+		t.Parallel()
+
+		a, err := analyzer.NewAnalyzer(analyzer.Config{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"inspect"}, requires(a))
+	})
+
+	t.Run("facts are required by config", func(t *testing.T) {
+		t.Parallel()
+
+		a, err := analyzer.NewAnalyzer(analyzer.Config{CheckConstructors: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"inspect", "exhaustruct_facts"}, requires(a))
+	})
+
+	t.Run("facts are required by flag", func(t *testing.T) {
+		t.Parallel()
+
+		a, err := analyzer.NewAnalyzer(analyzer.Config{})
+		require.NoError(t, err)
+		require.NoError(t, a.Flags.Parse([]string{"-check-constructors"}))
+		assert.Equal(t, []string{"inspect", "exhaustruct_facts"}, requires(a))
+
+		require.NoError(t, a.Flags.Parse([]string{"-check-constructors=false"}))
+		assert.Equal(t, []string{"inspect"}, requires(a))
+	})
+
+	t.Run("facts are required by profile", func(t *testing.T) {
+		t.Parallel()
+
+		a, err := analyzer.NewAnalyzer(analyzer.Config{
+			Profiles: map[string][]string{"strict": {"check-constructors"}},
+		})
+		require.NoError(t, err)
+		require.NoError(t, a.Flags.Parse([]string{"-profile", "strict"}))
+		assert.Equal(t, []string{"inspect", "exhaustruct_facts"}, requires(a))
+	})
+}
@@ -0,0 +1,22 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestAnalyzerDeep(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{
+		IncludeRx: []string{`.*\.Server`},
+		Deep:      true,
+	})
+	require.NoError(t, err)
+
+	analysistest.Run(t, testdataPath, a, "deep")
+}
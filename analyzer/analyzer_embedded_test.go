@@ -0,0 +1,47 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestAnalyzerEmbeddedPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		policy      analyzer.EmbeddedPolicy
+		testPackage string
+	}{
+		{
+			name:        "embedded fields are required by default",
+			policy:      "",
+			testPackage: "embedded_required",
+		},
+		{
+			name:        "embedded fields are optional",
+			policy:      analyzer.EmbeddedOptional,
+			testPackage: "embedded_optional",
+		},
+		{
+			name:        "embedded fields are required if embedded type has required fields",
+			policy:      analyzer.EmbeddedIfRequired,
+			testPackage: "embedded_if_required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := analyzer.NewAnalyzer(analyzer.Config{Embedded: tt.policy})
+			require.NoError(t, err)
+
+			analysistest.Run(t, testdataPath, a, tt.testPackage)
+		})
+	}
+}
@@ -45,6 +45,46 @@ func TestAnalyzerEmpty(t *testing.T) {
 			},
 			testPackage: "empty_patterns",
 		},
+		{
+			name: "context policies",
+			config: analyzer.Config{
+				AllowEmptyDeclarations: true,
+				ContextPolicies: map[analyzer.LiteralContext]analyzer.Policy{
+					analyzer.ContextReturn:             analyzer.PolicyAllowPartial,
+					analyzer.ContextDeclaration:        analyzer.PolicyStrict,
+					analyzer.ContextAssignment:         analyzer.PolicyAllowPartial,
+					analyzer.ContextCallArgument:       analyzer.PolicyAllowEmpty,
+					analyzer.ContextMapValue:           analyzer.PolicyAllowEmpty,
+					analyzer.ContextSliceElement:       analyzer.PolicyStrict,
+					analyzer.ContextChannelSend:        analyzer.PolicyAllowPartial,
+					analyzer.ContextFieldValue:         analyzer.PolicyAllowEmpty,
+					analyzer.ContextComparison:         analyzer.PolicyAllowEmpty,
+					analyzer.ContextInterfaceAssertion: analyzer.PolicyAllowEmpty,
+				},
+			},
+			testPackage: "context_policies",
+		},
+		{
+			name: "failure returns",
+			config: analyzer.Config{
+				FailureRx: []string{`.*\.Status`},
+			},
+			testPackage: "failure_returns",
+		},
+		{
+			name: "callee policies",
+			config: analyzer.Config{
+				ExcludeRx: []string{`.*\.Options`},
+				CalleeRules: []analyzer.CalleeRule{
+					{CalleeRx: `encoding/json\.Unmarshal`, Arg: 1, Policy: analyzer.PolicyAllowEmpty},
+					{CalleeRx: `errors\.As`, Arg: analyzer.AnyArg, Policy: analyzer.PolicyAllowPartial},
+					{CalleeRx: `\(\*callee_policies\.Client\)\.Do`, Arg: 1, Policy: analyzer.PolicyAllowEmpty},
+					{CalleeRx: `callee_policies\.NewServer`, Arg: 0, Policy: analyzer.PolicyStrict},
+					{CalleeRx: `encoding/json\.Unmarshal`, Arg: 1, Policy: analyzer.PolicyStrict},
+				},
+			},
+			testPackage: "callee_policies",
+		},
 		{
 			name:   "error returns behavior",
 			config: analyzer.Config{
@@ -0,0 +1,42 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestAnalyzerForbiddenFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		config       analyzer.Config
+		testPackages []string
+	}{
+		{
+			name:         "deprecated fields are checked",
+			config:       analyzer.Config{CheckDeprecated: true},
+			testPackages: []string{"forbidden", "forbidden_use"},
+		},
+		{
+			name:         "deprecated fields are not checked",
+			config:       analyzer.Config{},
+			testPackages: []string{"forbidden_unchecked"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := analyzer.NewAnalyzer(tt.config)
+			require.NoError(t, err)
+
+			analysistest.Run(t, testdataPath, a, tt.testPackages...)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestAnalyzerGenerated(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		config      analyzer.Config
+		testPackage string
+	}{
+		{
+			name: "generated files are skipped",
+			config: analyzer.Config{
+				GeneratedIncludeRx: []string{`.*\.Own.*`},
+			},
+			testPackage: "generated",
+		},
+		{
+			name: "generated files are checked",
+			config: analyzer.Config{
+				CheckGenerated: true,
+			},
+			testPackage: "generated_checked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := analyzer.NewAnalyzer(tt.config)
+			require.NoError(t, err)
+
+			analysistest.Run(t, testdataPath, a, tt.testPackage)
+		})
+	}
+}
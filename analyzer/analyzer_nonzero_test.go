@@ -0,0 +1,21 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestAnalyzerNonZero(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{
+		NonZeroRx: []string{`nonzero\.Route\.Name`},
+	})
+	require.NoError(t, err)
+
+	analysistest.Run(t, testdataPath, a, "nonzero")
+}
@@ -0,0 +1,54 @@
+package analyzer_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestAnalyzerScope(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		scope       analyzer.Scope
+		testPackage string
+	}{
+		{
+			name:        "all types are checked by default",
+			scope:       "",
+			testPackage: "./all",
+		},
+		{
+			name:        "types of other packages are checked",
+			scope:       analyzer.ScopeCrossPackage,
+			testPackage: "./cross_package",
+		},
+		{
+			name:        "types of other modules are checked",
+			scope:       analyzer.ScopeCrossModule,
+			testPackage: "./cross_module",
+		},
+		{
+			name:        "types of the same module are checked",
+			scope:       analyzer.ScopeSameModule,
+			testPackage: "./same_module",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := analyzer.NewAnalyzer(analyzer.Config{Scope: tt.scope})
+			require.NoError(t, err)
+
+			// scope testdata is a module, requiring another local module
+			analysistest.Run(t, filepath.Join(analysistest.TestData(), "scope"), a, tt.testPackage)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestAnalyzerSeverity(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{
+		Severity:  analyzer.SeverityInfo,
+		ErrorRx:   []string{`.*\.Payment.*`},
+		WarningRx: []string{`.*\.Notification`, `.*\.Payment.*`},
+	})
+	require.NoError(t, err)
+
+	results := analysistest.Run(t, testdataPath, a, "severity")
+	require.Len(t, results, 1)
+
+	res, ok := results[0].Result.(*analyzer.Result)
+	require.True(t, ok)
+
+	severities := make(map[string]analyzer.Severity)
+	for _, f := range res.Findings {
+		severities[f.Diagnostic.Message] = f.Severity
+	}
+
+	assert.Equal(t, map[string]analyzer.Severity{
+		"severity.PaymentRequest is missing field Currency": analyzer.SeverityError,
+		"severity.Notification is missing field Text":       analyzer.SeverityWarning,
+		"severity.Metadata is missing field Value":          analyzer.SeverityInfo,
+	}, severities)
+}
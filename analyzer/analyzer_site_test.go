@@ -0,0 +1,40 @@
+package analyzer_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestAnalyzerSites(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{
+		ExcludeSiteRx: []string{`.*/testutil`},
+		SiteRules: []analyzer.SiteRule{
+			{SiteRx: `.*_test\.go`, TypeRx: `sites\.Request`, Policy: analyzer.PolicyAllowEmpty},
+		},
+	})
+	require.NoError(t, err)
+
+	analysistest.Run(t, testdataPath, a, "sites", "sites/testutil")
+}
+
+func TestAnalyzerSites_ModuleRelativePaths(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{
+		ExcludeSiteRx: []string{`examples/.*`, `internal/testutil/.*`},
+		SiteRules: []analyzer.SiteRule{
+			{SiteRx: `app/.*_test\.go`, TypeRx: `.*/app\.Request`, Policy: analyzer.PolicyAllowEmpty},
+		},
+	})
+	require.NoError(t, err)
+
+	// sites testdata is a module, so file paths are relative to its root
+	analysistest.Run(t, filepath.Join(analysistest.TestData(), "sites"), a, "./...")
+}
@@ -0,0 +1,23 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestAnalyzerTags(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{
+		TagKey:       "lint",
+		OptionalTags: []string{"json:omitempty", "default"},
+		RequiredTags: []string{"validate=required"},
+	})
+	require.NoError(t, err)
+
+	analysistest.Run(t, testdataPath, a, "tags")
+}
@@ -40,3 +40,69 @@ func TestAnalyzer(t *testing.T) {
 
 	analysistest.Run(t, testdataPath, a, "i", "e")
 }
+
+func TestAnalyzerNolint(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{HonorNolint: true})
+	require.NoError(t, err)
+
+	analysistest.Run(t, testdataPath, a, "nolint")
+}
+
+func TestAnalyzerPackageConfig(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{})
+	require.NoError(t, err)
+
+	analysistest.Run(t, testdataPath, a, "package_config")
+}
+
+func TestAnalyzerProfiles(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{
+		Profiles: map[string][]string{
+			"strict": {},
+			"tests":  {"allow-empty", "honor-nolint"},
+		},
+	}, analyzer.WithName("exhaustruct_tests"), analyzer.WithDoc("Checks structures in tests"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "exhaustruct_tests", a.Name)
+	assert.Equal(t, "Checks structures in tests", a.Doc)
+
+	require.NoError(t, a.Flags.Set("profile", "tests"))
+
+	analysistest.Run(t, testdataPath, a, "profiles")
+}
+
+func TestNewAnalyzer_InvalidName(t *testing.T) {
+	t.Parallel()
+
+	_, err := analyzer.NewAnalyzer(analyzer.Config{}, analyzer.WithName("exhaustruct-tests"))
+	require.Error(t, err)
+}
+
+func TestAnalyzerTypeNames(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{
+		ExcludeRx: []string{`.*\.excludedFunc\..*`, `.*\.excludedVar\..*`},
+	})
+	require.NoError(t, err)
+
+	analysistest.Run(t, testdataPath, a, "type_names")
+}
+
+func TestAnalyzerTypeNames_Legacy(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{
+		ExcludeRx: []string{`type_names_legacy\.<anonymous>`, `type_names_legacy\.Local`},
+	})
+	require.NoError(t, err)
+
+	analysistest.Run(t, testdataPath, a, "type_names_legacy")
+}
@@ -0,0 +1,26 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestAnalyzerThresholds(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{
+		Thresholds: []analyzer.Threshold{
+			{TypeRx: `.*\.WideOptions`, Mode: analyzer.ThresholdMaxMissing, Value: 3},
+			{TypeRx: `.*\.HalfSet`, Mode: analyzer.ThresholdMinSetPercent, Value: 50},
+			{TypeRx: `.*\.AnyField`, Mode: analyzer.ThresholdNonEmpty},
+			{TypeRx: `.*\.AnyField`, Mode: analyzer.ThresholdMaxMissing, Value: 100},
+		},
+	})
+	require.NoError(t, err)
+
+	analysistest.Run(t, testdataPath, a, "thresholds")
+}
@@ -0,0 +1,199 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"dev.gaijin.team/go/exhaustruct/v4/internal/pattern"
+)
+
+// AnyArg is a value of [CalleeRule.Arg] that matches any argument.
+const AnyArg = -1
+
+// CalleeRule defines a policy for structures passed as arguments to matching
+// functions or methods, e.g. decode targets of `json.Unmarshal` are meant to be
+// empty, while options passed to constructors should always be exhaustive.
+type CalleeRule struct {
+	// CalleeRx is a regular expression to match full name of a callee, e.g.
+	// `encoding/json\.Unmarshal` for functions or `\(\*database/sql\.DB\)\.Query`
+	// for methods.
+	CalleeRx string
+	pattern  pattern.List `exhaustruct:"optional"`
+
+	// Arg is a zero-based index of an argument rule applies to. [AnyArg] matches
+	// any argument.
+	Arg int
+
+	// Policy is applied to structures passed as matching argument. Unlike
+	// context policies, [PolicyStrict] enforces check of structures regardless
+	// of any other configuration, except of ignore directive.
+	Policy Policy
+}
+
+// ParseCalleeRule parses callee rule from string in form of
+// `<callee-rx>:<arg>=<policy>`, where arg is either zero-based argument index
+// or `*` for any argument, e.g. `encoding/json\.Unmarshal:1=allow-empty`.
+func ParseCalleeRule(str string) (CalleeRule, error) {
+	errInvalid := e.New("callee rule must be in form of <callee-rx>:<arg>=<policy>", fields.F("callee-rule", str))
+
+	rest, policy, ok := cutLast(str, "=")
+	if !ok {
+		return CalleeRule{}, errInvalid
+	}
+
+	callee, arg, ok := cutLast(rest, ":")
+	if !ok {
+		return CalleeRule{}, errInvalid
+	}
+
+	r := CalleeRule{
+		CalleeRx: callee,
+		Arg:      AnyArg,
+		Policy:   Policy(policy),
+	}
+
+	if arg != "*" {
+		idx, err := strconv.Atoi(arg)
+		if err != nil {
+			return CalleeRule{}, e.NewFrom("parse callee rule argument index", err, fields.F("callee-rule", str))
+		}
+
+		r.Arg = idx
+	}
+
+	return r, nil
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (string, string, bool) {
+	idx := strings.LastIndex(s, sep)
+	if idx == -1 {
+		return s, "", false
+	}
+
+	return s[:idx], s[idx+len(sep):], true
+}
+
+// prepare validates callee rule and compiles its pattern.
+func (r *CalleeRule) prepare() error {
+	var err error
+
+	if _, err = ParsePolicy(string(r.Policy)); err != nil {
+		return err
+	}
+
+	if r.Arg < AnyArg {
+		return e.New("argument index must not be negative", fields.F("arg", r.Arg))
+	}
+
+	r.pattern, err = pattern.NewList(r.CalleeRx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// matches checks whether the rule applies to argument of a given callee.
+func (r *CalleeRule) matches(callee string, arg int) bool {
+	return (r.Arg == AnyArg || r.Arg == arg) && r.pattern.MatchFullString(callee)
+}
+
+// String returns callee rule in the same form it is parsed by [ParseCalleeRule].
+func (r CalleeRule) String() string {
+	arg := "*"
+	if r.Arg != AnyArg {
+		arg = strconv.Itoa(r.Arg)
+	}
+
+	return r.CalleeRx + ":" + arg + "=" + string(r.Policy)
+}
+
+// calleeRulesFlag implements flag.Value interface for []CalleeRule fields.
+type calleeRulesFlag struct {
+	slice *[]CalleeRule
+}
+
+func (f calleeRulesFlag) String() string {
+	if f.slice == nil {
+		return ""
+	}
+
+	s := make([]string, 0, len(*f.slice))
+	for _, r := range *f.slice {
+		s = append(s, r.String())
+	}
+
+	return strings.Join(s, ",")
+}
+
+func (f calleeRulesFlag) Set(value string) error {
+	r, err := ParseCalleeRule(value)
+	if err != nil {
+		return err
+	}
+
+	*f.slice = append(*f.slice, r)
+
+	return nil
+}
+
+// getCalleePolicy returns a policy of the first callee rule matching the call
+// literal is passed to as an argument, if any.
+func (a *analyzer) getCalleePolicy(pass *analysis.Pass, stack []ast.Node, lc literalContext) (Policy, bool) {
+	if len(a.config.CalleeRules) == 0 || lc.Kind != ContextCallArgument {
+		return "", false
+	}
+
+	call, ok := lc.Node.(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return "", false
+	}
+
+	arg := getCallArgIndex(stack, call)
+	if arg == -1 {
+		return "", false
+	}
+
+	name := fn.Origin().FullName()
+
+	for i := range a.config.CalleeRules {
+		if a.config.CalleeRules[i].matches(name, arg) {
+			return a.config.CalleeRules[i].Policy, true
+		}
+	}
+
+	return "", false
+}
+
+// getCallArgIndex returns an index of call argument that contains the top of
+// the stack, or -1 if there is no such argument.
+func getCallArgIndex(stack []ast.Node, call *ast.CallExpr) int {
+	for i := len(stack) - 1; i > 0; i-- {
+		if stack[i-1] != call {
+			continue
+		}
+
+		for j, arg := range call.Args {
+			if arg == stack[i] {
+				return j
+			}
+		}
+
+		break
+	}
+
+	return -1
+}
@@ -2,16 +2,73 @@ package analyzer
 
 import (
 	"flag"
+	"io"
+	"maps"
+	"slices"
 	"strings"
 
 	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
 
 	"dev.gaijin.team/go/exhaustruct/v4/internal/pattern"
+	"dev.gaijin.team/go/exhaustruct/v4/internal/structure"
 )
 
+// Severity is a level of reported issue.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// ParseSeverity converts a string into [Severity]. Empty string is treated as
+// [SeverityError].
+func ParseSeverity(s string) (Severity, error) {
+	switch sev := Severity(s); sev {
+	case "":
+		return SeverityError, nil
+	case SeverityError, SeverityWarning, SeverityInfo:
+		return sev, nil
+	default:
+		return "", e.New("unknown severity", fields.F("severity", s))
+	}
+}
+
+// Set implements [flag.Value] interface.
+func (s *Severity) Set(value string) error {
+	sev, err := ParseSeverity(value)
+	if err != nil {
+		return err
+	}
+
+	*s = sev
+
+	return nil
+}
+
+// String implements [flag.Value] interface.
+func (s *Severity) String() string {
+	if s == nil {
+		return ""
+	}
+
+	return string(*s)
+}
+
+// Config is a configuration of the analyzer.
+//
+// Type names used in patterns include package path. Types declared inside
+// functions are qualified by function name, e.g. `pkg.run.localType`.
+// Anonymous structs are named '<anonymous>' and qualified by the field they
+// are value of, e.g. `pkg.Config.HTTP.<anonymous>`, or otherwise by the
+// function they are used in, e.g. `pkg.handler.<anonymous>`. Methods are
+// qualified by receiver type name, e.g. `pkg.Server.handle.<anonymous>`.
 type Config struct {
 	// IncludeRx is a list of regular expressions to match type names that should be
-	// processed. Anonymous structs can be matched by '<anonymous>' alias.
+	// processed. Anonymous structs can be matched by '<anonymous>' alias, see
+	// [Config] for naming of anonymous and function-local types.
 	//
 	// Each regular expression must match the full type name, including package path.
 	// For example, to match type `net/http.Cookie` regular expression should be
@@ -31,6 +88,35 @@ type Config struct {
 	ExcludeRx       []string     `exhaustruct:"optional"`
 	excludePatterns pattern.List `exhaustruct:"optional"`
 
+	// IncludeSiteRx is a list of regular expressions to match sites, literals
+	// written at which should be processed. Site is either package path or file
+	// path of the place literal is written in, e.g. `.*/internal/testutil` or
+	// `.*_test\.go`. File path is relative to module root, e.g. `examples/.*`,
+	// or absolute in case module is unknown. Regular expression must match any
+	// of them fully.
+	IncludeSiteRx       []string     `exhaustruct:"optional"`
+	includeSitePatterns pattern.List `exhaustruct:"optional"`
+
+	// ExcludeSiteRx is a list of regular expressions to match sites, literals
+	// written at which should be excluded from processing, see IncludeSiteRx.
+	//
+	// Has precedence over IncludeSiteRx.
+	ExcludeSiteRx       []string     `exhaustruct:"optional"`
+	excludeSitePatterns pattern.List `exhaustruct:"optional"`
+
+	// SiteRules is a list of policies for structures of matching types, written
+	// at matching sites, e.g. to allow empty requests only in tests. First
+	// matching rule is applied. Site rules have precedence over any other empty
+	// and partial allowances, except of callee rules.
+	SiteRules []SiteRule `exhaustruct:"optional"`
+
+	// Scope limits checked literals to types declared in other packages, other
+	// modules or the same module, relative to the package literal is located
+	// in. Packages outside of module path belong to other modules, while ones
+	// within it are told apart from nested modules by location of their
+	// nearest go.mod files. Empty value is treated as [ScopeAll].
+	Scope Scope `exhaustruct:"optional"`
+
 	// AllowEmpty allows empty structures, effectively excluding them from the check.
 	AllowEmpty bool `exhaustruct:"optional"`
 
@@ -49,12 +135,163 @@ type Config struct {
 
 	// AllowEmptyDeclarations allows empty structures in variable declarations.
 	AllowEmptyDeclarations bool `exhaustruct:"optional"`
+
+	// FailureRx is a list of regular expressions to match type names of failure
+	// sentinels. Return statement containing non-nil value of such type is
+	// treated as a failure path, in which empty and partially initialized
+	// structures are allowed, same as in comma-ok returns with `false` value.
+	//
+	// Each regular expression must match the full type name, including package path.
+	FailureRx       []string     `exhaustruct:"optional"`
+	failurePatterns pattern.List `exhaustruct:"optional"`
+
+	// CalleeRules is a list of policies for structures passed as arguments to
+	// matching functions and methods. First matching rule is applied. Callee
+	// rules have precedence over any other empty and partial allowances.
+	CalleeRules []CalleeRule `exhaustruct:"optional"`
+
+	// ContextPolicies defines how incomplete structures are treated depending
+	// on syntactic context they are used in, e.g. function call arguments or
+	// map values. Has precedence over AllowEmptyReturns and
+	// AllowEmptyDeclarations.
+	ContextPolicies map[LiteralContext]Policy `exhaustruct:"optional"`
+
+	// HonorNolint enables recognition of suppression comments of other tools:
+	// `//nolint:exhaustruct`, `//nolint:all` and `//lint:ignore exhaustruct`,
+	// which are treated the same way as `//exhaustruct:ignore` directive.
+	//
+	// Useful when analyzer is run as a standalone binary or as a vet tool, while
+	// golangci-lint handles such comments on its own.
+	HonorNolint bool `exhaustruct:"optional"`
+
+	// Severity is a default severity of reported issues. Empty value is treated
+	// as [SeverityError].
+	Severity Severity `exhaustruct:"optional"`
+
+	// ErrorRx is a list of regular expressions to match type names, issues of
+	// which should be reported with [SeverityError]. Has precedence over
+	// WarningRx and InfoRx.
+	//
+	// Each regular expression must match the full type name, including package path.
+	ErrorRx       []string     `exhaustruct:"optional"`
+	errorPatterns pattern.List `exhaustruct:"optional"`
+
+	// WarningRx is a list of regular expressions to match type names, issues of
+	// which should be reported with [SeverityWarning]. Has precedence over InfoRx.
+	//
+	// Each regular expression must match the full type name, including package path.
+	WarningRx       []string     `exhaustruct:"optional"`
+	warningPatterns pattern.List `exhaustruct:"optional"`
+
+	// InfoRx is a list of regular expressions to match type names, issues of
+	// which should be reported with [SeverityInfo].
+	//
+	// Each regular expression must match the full type name, including package path.
+	InfoRx       []string     `exhaustruct:"optional"`
+	infoPatterns pattern.List `exhaustruct:"optional"`
+
+	// Thresholds is a list of rules relaxing reporting of partially initialized
+	// literals of matching types. First matching threshold is applied.
+	Thresholds []Threshold `exhaustruct:"optional"`
+
+	// CheckGenerated enables checking of generated files, which are skipped by
+	// default. File is considered generated if it has a standard
+	// `// Code generated ... DO NOT EDIT.` header.
+	CheckGenerated bool `exhaustruct:"optional"`
+
+	// GeneratedIncludeRx is a list of regular expressions to match type names
+	// that should be checked even inside generated files.
+	//
+	// Each regular expression must match the full type name, including package path.
+	GeneratedIncludeRx       []string     `exhaustruct:"optional"`
+	generatedIncludePatterns pattern.List `exhaustruct:"optional"`
+
+	// NonZeroRx is a list of regular expressions to match fields, that must not
+	// be set to constant zero value (`nil`, `""`, `0`, `false` or an empty
+	// literal), same as fields marked with `exhaustruct:"nonzero"` tag.
+	//
+	// Each regular expression must match the full field name, including package
+	// path and type name, e.g. `.*/http\.Cookie\.Name`.
+	NonZeroRx       []string     `exhaustruct:"optional"`
+	nonZeroPatterns pattern.List `exhaustruct:"optional"`
+
+	// CheckConstructors enables reporting of literals of types annotated with
+	// `//exhaustruct:constructor` directive outside of their package. Directives
+	// are collected as facts from all dependencies, so drivers have to analyze
+	// them as well.
+	CheckConstructors bool `exhaustruct:"optional"`
+
+	// CheckDeprecated enables treating fields documented with `Deprecated:`
+	// paragraph as forbidden: they are not required, and literals setting them
+	// are reported, including ones in the package structure is declared in.
+	// Deprecations are collected as facts from all dependencies, so drivers
+	// have to analyze them as well.
+	CheckDeprecated bool `exhaustruct:"optional"`
+
+	// DetectConstructors enables reporting of literals of types with unexported
+	// fields outside of their package, in case the package exports `NewT`
+	// function returning `T` or `*T`. It does not rely on facts, so it works
+	// regardless of CheckConstructors.
+	DetectConstructors bool `exhaustruct:"optional"`
+
+	// Deep enables checking of nested literals of value (non-pointer) struct
+	// fields as parts of the enclosing literal: once the enclosing literal is
+	// checked, nested ones are checked as well, regardless of type patterns and
+	// context policies, and missing fields are reported by path, e.g.
+	// `pkg.Server.TLS.CertFile`.
+	Deep bool `exhaustruct:"optional"`
+
+	// Embedded is a policy of embedded fields: they are either required same
+	// as any other field, optional, or required only in case embedded
+	// structure has required fields itself. Empty value is treated as
+	// [EmbeddedRequired].
+	Embedded EmbeddedPolicy `exhaustruct:"optional"`
+
+	// AliasMatching defines which name of a structure, referred by a type alias
+	// at literal site, is matched by type patterns: the name of aliased type,
+	// the name of alias, or both. Empty value is treated as
+	// [AliasMatchingTarget].
+	AliasMatching AliasMatching `exhaustruct:"optional"`
+
+	// TagKey is a key of field tags holding exhaustruct annotations, e.g.
+	// `exhaustruct:"optional"`. Empty value is treated as `exhaustruct`.
+	TagKey string `exhaustruct:"optional"`
+
+	// OptionalTags is a list of tags of other libraries marking fields
+	// optional, in form of `<key>[:<value>]`, where value is one of
+	// comma-separated tag options following the name part, e.g.
+	// `json:omitempty`, or `<key>=<value>` for tags having no name part, e.g.
+	// `validate=required`. Tag without value matches any tag with given key,
+	// e.g. `default`.
+	OptionalTags []string `exhaustruct:"optional"`
+
+	// RequiredTags is a list of tags of other libraries marking fields
+	// required, in the same form as OptionalTags, e.g. `validate=required`.
+	// Has precedence over OptionalTags, but not over `exhaustruct:"optional"`
+	// annotation.
+	RequiredTags []string `exhaustruct:"optional"`
+
+	// fieldTags is compiled tags configuration.
+	fieldTags structure.Tags `exhaustruct:"optional"`
+
+	// Profiles is a set of named configuration profiles. Each profile is a list
+	// of settings, named same as flags without leading dash, e.g.
+	// `allow-empty-returns` or `include-rx=.*\.Test`. Settings of selected
+	// profile are applied on top of the configuration, the same way as flags.
+	Profiles map[string][]string `exhaustruct:"optional"`
+
+	// Profile is a name of selected profile from Profiles. Empty value means
+	// that no profile is applied.
+	Profile string `exhaustruct:"optional"`
 }
 
 // Prepare compiles all regular expression patterns into pattern lists for
 // efficient matching.
 func (c *Config) Prepare() error {
-	var err error
+	err := c.validateProfiles()
+	if err != nil {
+		return err
+	}
 
 	c.includePatterns, err = pattern.NewList(c.IncludeRx...)
 	if err != nil {
@@ -66,14 +303,249 @@ func (c *Config) Prepare() error {
 		return e.NewFrom("compile exclude patterns", err)
 	}
 
+	c.includeSitePatterns, err = pattern.NewList(c.IncludeSiteRx...)
+	if err != nil {
+		return e.NewFrom("compile include site patterns", err)
+	}
+
+	c.excludeSitePatterns, err = pattern.NewList(c.ExcludeSiteRx...)
+	if err != nil {
+		return e.NewFrom("compile exclude site patterns", err)
+	}
+
+	for i := range c.SiteRules {
+		if err = c.SiteRules[i].prepare(); err != nil {
+			return e.NewFrom("prepare site rule", err, fields.F("site-rule", c.SiteRules[i].String()))
+		}
+	}
+
 	c.allowEmptyPatterns, err = pattern.NewList(c.AllowEmptyRx...)
 	if err != nil {
 		return e.NewFrom("compile allow empty patterns", err)
 	}
 
+	c.failurePatterns, err = pattern.NewList(c.FailureRx...)
+	if err != nil {
+		return e.NewFrom("compile failure patterns", err)
+	}
+
+	for i := range c.CalleeRules {
+		if err = c.CalleeRules[i].prepare(); err != nil {
+			return e.NewFrom("prepare callee rule", err, fields.F("callee-rule", c.CalleeRules[i].String()))
+		}
+	}
+
+	for ctx, p := range c.ContextPolicies {
+		if !isKnownContext(ctx) {
+			return e.New("unknown context", fields.F("context", ctx))
+		}
+
+		if _, err = ParsePolicy(string(p)); err != nil {
+			return e.NewFrom("parse context policy", err, fields.F("context", ctx))
+		}
+	}
+
+	c.Scope, err = ParseScope(string(c.Scope))
+	if err != nil {
+		return e.NewFrom("parse scope", err)
+	}
+
+	c.Severity, err = ParseSeverity(string(c.Severity))
+	if err != nil {
+		return e.NewFrom("parse severity", err)
+	}
+
+	c.Embedded, err = ParseEmbeddedPolicy(string(c.Embedded))
+	if err != nil {
+		return e.NewFrom("parse embedded policy", err)
+	}
+
+	c.AliasMatching, err = ParseAliasMatching(string(c.AliasMatching))
+	if err != nil {
+		return e.NewFrom("parse alias matching", err)
+	}
+
+	c.errorPatterns, err = pattern.NewList(c.ErrorRx...)
+	if err != nil {
+		return e.NewFrom("compile error severity patterns", err)
+	}
+
+	c.warningPatterns, err = pattern.NewList(c.WarningRx...)
+	if err != nil {
+		return e.NewFrom("compile warning severity patterns", err)
+	}
+
+	c.infoPatterns, err = pattern.NewList(c.InfoRx...)
+	if err != nil {
+		return e.NewFrom("compile info severity patterns", err)
+	}
+
+	c.generatedIncludePatterns, err = pattern.NewList(c.GeneratedIncludeRx...)
+	if err != nil {
+		return e.NewFrom("compile generated include patterns", err)
+	}
+
+	c.nonZeroPatterns, err = pattern.NewList(c.NonZeroRx...)
+	if err != nil {
+		return e.NewFrom("compile non-zero patterns", err)
+	}
+
+	c.fieldTags, err = c.prepareFieldTags()
+	if err != nil {
+		return err
+	}
+
+	for i := range c.Thresholds {
+		if err = c.Thresholds[i].prepare(); err != nil {
+			return e.NewFrom("prepare threshold", err, fields.F("threshold", c.Thresholds[i].String()))
+		}
+	}
+
 	return nil
 }
 
+// FieldTags returns field tags configuration under a given config and its
+// selected profile, so tools other than the analyzer, e.g. constructors
+// generator, interpret field tags the same way.
+func FieldTags(config Config) (structure.Tags, error) {
+	cfg, err := config.withProfile()
+	if err != nil {
+		return structure.Tags{}, err //nolint:exhaustruct
+	}
+
+	if err = cfg.Prepare(); err != nil {
+		return structure.Tags{}, err //nolint:exhaustruct
+	}
+
+	return cfg.fieldTags, nil
+}
+
+// prepareFieldTags compiles tags configuration.
+func (c *Config) prepareFieldTags() (structure.Tags, error) {
+	if strings.ContainsAny(c.TagKey, " \t\":") {
+		return structure.Tags{}, e.New("invalid tag key", fields.F("tag-key", c.TagKey)) //nolint:exhaustruct
+	}
+
+	tags := structure.Tags{
+		Key:      c.TagKey,
+		Optional: make([]structure.TagRule, 0, len(c.OptionalTags)),
+		Required: make([]structure.TagRule, 0, len(c.RequiredTags)),
+	}
+
+	for _, s := range c.OptionalTags {
+		r, err := structure.ParseTagRule(s)
+		if err != nil {
+			return tags, e.NewFrom("parse optional tag", err)
+		}
+
+		tags.Optional = append(tags.Optional, r)
+	}
+
+	for _, s := range c.RequiredTags {
+		r, err := structure.ParseTagRule(s)
+		if err != nil {
+			return tags, e.NewFrom("parse required tag", err)
+		}
+
+		tags.Required = append(tags.Required, r)
+	}
+
+	return tags, nil
+}
+
+// thresholdOf returns the first threshold matching any of given type names, if
+// any.
+func (c *Config) thresholdOf(typeNames ...string) (*Threshold, bool) {
+	for i := range c.Thresholds {
+		if matchAny(c.Thresholds[i].pattern, typeNames) {
+			return &c.Thresholds[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// contextPolicy returns the policy applied to structures used in a given
+// context.
+func (c *Config) contextPolicy(ctx LiteralContext) Policy {
+	if p, ok := c.ContextPolicies[ctx]; ok {
+		return p
+	}
+
+	switch {
+	case ctx == ContextReturn && c.AllowEmptyReturns:
+		return PolicyAllowEmpty
+	case (ctx == ContextDeclaration || ctx == ContextInterfaceAssertion) && c.AllowEmptyDeclarations:
+		return PolicyAllowEmpty
+	default:
+		return PolicyStrict
+	}
+}
+
+// severityOf returns the severity issues of a type with given names should be
+// reported with.
+func (c *Config) severityOf(typeNames ...string) Severity {
+	switch {
+	case matchAny(c.errorPatterns, typeNames):
+		return SeverityError
+	case matchAny(c.warningPatterns, typeNames):
+		return SeverityWarning
+	case matchAny(c.infoPatterns, typeNames):
+		return SeverityInfo
+	default:
+		return c.defaultSeverity()
+	}
+}
+
+// needsFacts checks whether any of enabled features relies on facts collected
+// from dependencies by [factsAnalyzer].
+func (c *Config) needsFacts() bool {
+	return c.CheckConstructors || c.CheckDeprecated
+}
+
+// defaultSeverity returns the severity of issues that are not related to any
+// specific type.
+func (c *Config) defaultSeverity() Severity {
+	if c.Severity == "" {
+		return SeverityError
+	}
+
+	return c.Severity
+}
+
+// clone returns a deep copy of the config, so modifications of the copy never
+// affect the original one.
+func (c *Config) clone() Config {
+	cc := *c
+
+	cc.IncludeRx = slices.Clone(c.IncludeRx)
+	cc.ExcludeRx = slices.Clone(c.ExcludeRx)
+	cc.AllowEmptyRx = slices.Clone(c.AllowEmptyRx)
+	cc.FailureRx = slices.Clone(c.FailureRx)
+	cc.CalleeRules = slices.Clone(c.CalleeRules)
+	cc.IncludeSiteRx = slices.Clone(c.IncludeSiteRx)
+	cc.ExcludeSiteRx = slices.Clone(c.ExcludeSiteRx)
+	cc.SiteRules = slices.Clone(c.SiteRules)
+	cc.ContextPolicies = maps.Clone(c.ContextPolicies)
+	cc.ErrorRx = slices.Clone(c.ErrorRx)
+	cc.WarningRx = slices.Clone(c.WarningRx)
+	cc.InfoRx = slices.Clone(c.InfoRx)
+	cc.Thresholds = slices.Clone(c.Thresholds)
+	cc.GeneratedIncludeRx = slices.Clone(c.GeneratedIncludeRx)
+	cc.NonZeroRx = slices.Clone(c.NonZeroRx)
+	cc.OptionalTags = slices.Clone(c.OptionalTags)
+	cc.RequiredTags = slices.Clone(c.RequiredTags)
+
+	if c.Profiles != nil {
+		cc.Profiles = make(map[string][]string, len(c.Profiles))
+		for name, settings := range c.Profiles {
+			cc.Profiles[name] = slices.Clone(settings)
+		}
+	}
+
+	return cc
+}
+
 // stringSliceFlag implements flag.Value interface for []string fields.
 type stringSliceFlag struct {
 	slice *[]string
@@ -96,24 +568,47 @@ func (s stringSliceFlag) Set(value string) error {
 func (c *Config) BindToFlagSet(fs *flag.FlagSet) *flag.FlagSet {
 	fs.Var(stringSliceFlag{&c.IncludeRx}, "include-rx",
 		"Regular expression to match type names that should be processed. "+
-			"Anonymous structs can be matched by '<anonymous>' alias. "+
+			"Anonymous structs can be matched by '<anonymous>' alias, qualified by the field or function "+
+			"they are used in, e.g. `.*\\.Config\\.HTTP\\.<anonymous>`. "+
 			"Each regex must match the full type name including package path. "+
 			"Example: `.*/http\\.Cookie`. Can be used multiple times.")
 	fs.Var(stringSliceFlag{&c.IncludeRx}, "i", "Short form of -include-rx")
 
 	fs.Var(stringSliceFlag{&c.ExcludeRx}, "exclude-rx",
 		"Regular expression to exclude type names from processing, has precedence over -include. "+
-			"Anonymous structs can be matched by '<anonymous>' alias. "+
+			"Anonymous structs can be matched by '<anonymous>' alias, qualified by the field or function "+
+			"they are used in, e.g. `.*\\.Config\\.HTTP\\.<anonymous>`. "+
 			"Each regex must match the full type name including package path. "+
 			"Example: `.*/http\\.Cookie`. Can be used multiple times.")
 	fs.Var(stringSliceFlag{&c.ExcludeRx}, "e", "Short form of -exclude-rx")
 
+	fs.Var(stringSliceFlag{&c.IncludeSiteRx}, "include-site-rx",
+		"Regular expression to match package path or file path of sites, literals written at which "+
+			"should be processed. File path is relative to module root. Example: `internal/.*`. "+
+			"Can be used multiple times.")
+
+	fs.Var(stringSliceFlag{&c.ExcludeSiteRx}, "exclude-site-rx",
+		"Regular expression to match package path or file path of sites, literals written at which "+
+			"should be excluded from processing, has precedence over -include-site-rx. "+
+			"Example: `.*_test\\.go`. Can be used multiple times.")
+
+	fs.Var(siteRulesFlag{&c.SiteRules}, "site-policy",
+		"Policy for structures of matching types written at matching sites, in form of "+
+			"<site-rx>:<type-rx>=<policy>, e.g. `.*_test\\.go:.*/pkg\\.Request=allow-empty`. "+
+			"Site regular expression must not contain colons. "+
+			"Can be used multiple times, first matching rule is applied.")
+
+	fs.Var(&c.Scope, "scope",
+		"Scope of checked literals by package and module the type is declared in: "+
+			"all (default), cross-package, cross-module or same-module")
+
 	fs.BoolVar(&c.AllowEmpty, "allow-empty", c.AllowEmpty,
 		"Allow empty structures, effectively excluding them from the check")
 
 	fs.Var(stringSliceFlag{&c.AllowEmptyRx}, "allow-empty-rx",
 		"Regular expression to match type names that should be allowed to be empty. "+
-			"Anonymous structs can be matched by '<anonymous>' alias. "+
+			"Anonymous structs can be matched by '<anonymous>' alias, qualified by the field or function "+
+			"they are used in, e.g. `.*\\.Config\\.HTTP\\.<anonymous>`. "+
 			"Each regex must match the full type name including package path. "+
 			"Example: `.*/http\\.Cookie`. Can be used multiple times.")
 
@@ -123,5 +618,144 @@ func (c *Config) BindToFlagSet(fs *flag.FlagSet) *flag.FlagSet {
 	fs.BoolVar(&c.AllowEmptyDeclarations, "allow-empty-declarations", c.AllowEmptyDeclarations,
 		"Allow empty structures in variable declarations")
 
+	fs.Var(calleeRulesFlag{&c.CalleeRules}, "callee-policy",
+		"Policy for structures passed as arguments to matching functions, in form of "+
+			"`<callee-rx>:<arg>=<policy>`, where callee-rx matches full function name, e.g. "+
+			"`encoding/json\\.Unmarshal` or `\\(\\*database/sql\\.DB\\)\\.Query`, arg is a zero-based argument "+
+			"index or `*` for any argument, and policy is one of: strict (always check), allow-empty, "+
+			"allow-partial. Can be used multiple times.")
+
+	fs.Var(stringSliceFlag{&c.FailureRx}, "failure-rx",
+		"Regular expression to match type names of failure sentinels. Empty and partially initialized "+
+			"structures are allowed in return statements containing non-nil value of such type. "+
+			"Each regex must match the full type name including package path. Can be used multiple times.")
+
+	fs.Var(contextPoliciesFlag{&c.ContextPolicies}, "context-policy",
+		"Policy for structures used in specific syntactic context, in form of `<context>=<policy>`. "+
+			"Contexts: return, declaration, assignment, call-argument, map-value, slice-element, "+
+			"channel-send, field-value, comparison, interface-assertion. "+
+			"Policies: strict, allow-empty, allow-partial. Can be used multiple times.")
+
+	fs.BoolVar(&c.HonorNolint, "honor-nolint", c.HonorNolint,
+		"Treat //nolint:exhaustruct, //nolint:all and //lint:ignore exhaustruct comments "+
+			"the same way as //exhaustruct:ignore directive")
+
+	fs.Var(&c.Severity, "severity",
+		"Default severity of reported issues: error, warning or info. Defaults to error.")
+
+	fs.Var(stringSliceFlag{&c.ErrorRx}, "error-rx",
+		"Regular expression to match type names, issues of which should be reported as errors. "+
+			"Has precedence over -warning-rx and -info-rx. Can be used multiple times.")
+
+	fs.Var(stringSliceFlag{&c.WarningRx}, "warning-rx",
+		"Regular expression to match type names, issues of which should be reported as warnings. "+
+			"Has precedence over -info-rx. Can be used multiple times.")
+
+	fs.Var(stringSliceFlag{&c.InfoRx}, "info-rx",
+		"Regular expression to match type names, issues of which should be reported as info. "+
+			"Can be used multiple times.")
+
+	fs.Var(thresholdsFlag{&c.Thresholds}, "threshold",
+		"Relax reporting of partially initialized structures of matching types, in form of "+
+			"`<type-rx>=<mode>[:<value>]`, where mode is one of: max-missing (report only if more than "+
+			"value fields are missing), min-set-percent (report only if less than value percent of fields "+
+			"are set), non-empty (report only if no fields are set). Can be used multiple times.")
+
+	fs.BoolVar(&c.CheckGenerated, "check-generated", c.CheckGenerated,
+		"Check generated files, which are skipped by default")
+
+	fs.Var(stringSliceFlag{&c.GeneratedIncludeRx}, "generated-include-rx",
+		"Regular expression to match type names that should be checked even inside generated files. "+
+			"Each regex must match the full type name including package path. Can be used multiple times.")
+
+	fs.Var(stringSliceFlag{&c.NonZeroRx}, "nonzero-rx",
+		"Regular expression to match fields, including package path and type name, that must not be set "+
+			"to constant zero value. Example: `.*/http\\.Cookie\\.Name`. Can be used multiple times.")
+
+	fs.StringVar(&c.TagKey, "tag-key", c.TagKey,
+		"Key of field tags holding exhaustruct annotations, e.g. optional. Defaults to exhaustruct")
+
+	fs.Var(stringSliceFlag{&c.OptionalTags}, "optional-tag",
+		"Tag of other library marking fields optional, in form of <key>[:<value>] or <key>=<value>, "+
+			"e.g. `json:omitempty` or `default`. Can be used multiple times.")
+
+	fs.Var(stringSliceFlag{&c.RequiredTags}, "required-tag",
+		"Tag of other library marking fields required, in form of <key>[:<value>] or <key>=<value>, "+
+			"e.g. `validate=required`. "+
+			"Has precedence over -optional-tag. Can be used multiple times.")
+
+	fs.BoolVar(&c.CheckConstructors, "check-constructors", c.CheckConstructors,
+		"Report literals of types annotated with //exhaustruct:constructor directive outside of their package")
+
+	fs.BoolVar(&c.CheckDeprecated, "check-deprecated", c.CheckDeprecated,
+		"Treat deprecated fields as forbidden: do not require them and report literals setting them")
+
+	fs.BoolVar(&c.DetectConstructors, "detect-constructors", c.DetectConstructors,
+		"Report literals of types with unexported fields outside of their package, in case the package "+
+			"exports NewT function returning T or *T")
+
+	fs.BoolVar(&c.Deep, "deep", c.Deep,
+		"Check nested literals of value struct fields as parts of the enclosing literal, "+
+			"reporting missing fields by path")
+
+	fs.Var(&c.Embedded, "embedded",
+		"Policy of embedded fields: required (default), optional or if-required, "+
+			"requiring them only in case embedded structure has required fields")
+
+	fs.Var(&c.AliasMatching, "alias-matching",
+		"Which name of a structure, referred by a type alias, is matched by type patterns: "+
+			"target (aliased type, default), alias or both")
+
+	fs.StringVar(&c.Profile, "profile", c.Profile,
+		"Name of configuration profile to apply on top of other settings")
+
+	fs.Var(profileSettingsFlag{&c.Profiles}, "profile-setting",
+		"Setting of named configuration profile, in form of `<profile>:<setting>[=<value>]`, where "+
+			"setting is a name of any other flag without leading dash, e.g. `tests:allow-empty`. "+
+			"Can be used multiple times.")
+
 	return fs
 }
+
+// applySetting applies a setting to a copy of a given config, returning
+// updated config along with setting conflict key. Empty key means that setting
+// never conflicts with others. Settings without value are only allowed for
+// boolean flags, which are set to true in such case.
+func applySetting(config Config, name, value string, hasValue bool) (Config, string, error) {
+	updated := config.clone()
+
+	fs := updated.BindToFlagSet(flag.NewFlagSet("", flag.ContinueOnError))
+	fs.SetOutput(io.Discard)
+
+	f := fs.Lookup(name)
+	if f == nil {
+		return config, "", e.New("unknown setting", fields.F("setting", name))
+	}
+
+	if !hasValue {
+		if bf, ok := f.Value.(interface{ IsBoolFlag() bool }); !ok || !bf.IsBoolFlag() {
+			return config, "", e.New("setting requires a value", fields.F("setting", name))
+		}
+
+		value = "true"
+	}
+
+	if err := fs.Set(name, value); err != nil {
+		return config, "", err //nolint:wrapcheck
+	}
+
+	if err := updated.Prepare(); err != nil {
+		return config, "", err
+	}
+
+	key, conflicts := f.Name, true
+	if ck, ok := f.Value.(conflictKeyer); ok {
+		key, conflicts = ck.conflictKey(name, value)
+	}
+
+	if !conflicts {
+		return updated, "", nil
+	}
+
+	return updated, key, nil
+}
@@ -2,6 +2,7 @@ package analyzer
 
 import (
 	"flag"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -39,6 +40,59 @@ func TestConfig_Prepare(t *testing.T) {
 		assert.False(t, config.allowEmptyPatterns.MatchFullString("pkg.RegularStruct"))
 	})
 
+	t.Run("invalid alias matching", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{
+			AliasMatching: "unknown",
+		}
+
+		err := config.Prepare()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "parse alias matching")
+	})
+
+	t.Run("invalid field tags", func(t *testing.T) {
+		t.Parallel()
+
+		for _, tt := range []struct {
+			config Config
+			err    string
+		}{
+			{config: Config{TagKey: `lint:"x"`}, err: "invalid tag key"},
+			{config: Config{OptionalTags: []string{":omitempty"}}, err: "parse optional tag"},
+			{config: Config{RequiredTags: []string{""}}, err: "parse required tag"},
+		} {
+			err := tt.config.Prepare()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		}
+	})
+
+	t.Run("invalid scope", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{
+			Scope: "unknown",
+		}
+
+		err := config.Prepare()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "parse scope")
+	})
+
+	t.Run("invalid embedded policy", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{
+			Embedded: "unknown",
+		}
+
+		err := config.Prepare()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "parse embedded policy")
+	})
+
 	t.Run("invalid include pattern", func(t *testing.T) {
 		t.Parallel()
 
@@ -63,6 +117,30 @@ func TestConfig_Prepare(t *testing.T) {
 		assert.Contains(t, err.Error(), "compile exclude patterns")
 	})
 
+	t.Run("invalid generated include pattern", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{
+			GeneratedIncludeRx: []string{"[invalid"},
+		}
+
+		err := config.Prepare()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "compile generated include patterns")
+	})
+
+	t.Run("invalid failure pattern", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{
+			FailureRx: []string{"[invalid"},
+		}
+
+		err := config.Prepare()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "compile failure patterns")
+	})
+
 	t.Run("invalid allow empty pattern", func(t *testing.T) {
 		t.Parallel()
 
@@ -100,9 +178,15 @@ func TestConfig_BindToFlagSet(t *testing.T) {
 
 		// Check that flags are registered
 		expectedFlags := []string{
-			"include-rx", "i", "exclude-rx", "e",
+			"include-rx", "i", "exclude-rx", "e", "scope",
+			"include-site-rx", "exclude-site-rx", "site-policy",
 			"allow-empty", "allow-empty-rx",
 			"allow-empty-returns", "allow-empty-declarations",
+			"severity", "error-rx", "warning-rx", "info-rx",
+			"threshold", "check-generated", "generated-include-rx",
+			"context-policy", "failure-rx", "callee-policy", "honor-nolint",
+			"alias-matching", "nonzero-rx", "tag-key", "optional-tag", "required-tag",
+			"check-constructors", "check-deprecated", "detect-constructors", "deep", "embedded", "profile", "profile-setting",
 		}
 
 		for _, flagName := range expectedFlags {
@@ -143,13 +227,14 @@ func TestConfig_BindToFlagSet(t *testing.T) {
 		config := Config{}
 		fs := config.BindToFlagSet(flag.NewFlagSet("test", flag.ContinueOnError))
 
-		args := []string{"-allow-empty", "-allow-empty-returns", "-allow-empty-declarations"}
+		args := []string{"-allow-empty", "-allow-empty-returns", "-allow-empty-declarations", "-check-generated"}
 		err := fs.Parse(args)
 		require.NoError(t, err)
 
 		assert.True(t, config.AllowEmpty)
 		assert.True(t, config.AllowEmptyReturns)
 		assert.True(t, config.AllowEmptyDeclarations)
+		assert.True(t, config.CheckGenerated)
 	})
 
 	t.Run("flag parsing allow-empty-rx patterns", func(t *testing.T) {
@@ -166,6 +251,368 @@ func TestConfig_BindToFlagSet(t *testing.T) {
 	})
 }
 
+func TestConfig_ContextPolicies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("flag parsing context policies", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{}
+		fs := config.BindToFlagSet(flag.NewFlagSet("test", flag.ContinueOnError))
+		fs.SetOutput(io.Discard)
+
+		args := []string{
+			"-context-policy", "call-argument=allow-empty",
+			"-context-policy", "map-value=allow-partial",
+			"-context-policy", "call-argument=strict",
+		}
+		err := fs.Parse(args)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[LiteralContext]Policy{
+			ContextCallArgument: PolicyStrict,
+			ContextMapValue:     PolicyAllowPartial,
+		}, config.ContextPolicies)
+		assert.Equal(t, "call-argument=strict,map-value=allow-partial", fs.Lookup("context-policy").Value.String())
+
+		assert.Error(t, fs.Parse([]string{"-context-policy", "call-argument"}))
+		assert.Error(t, fs.Parse([]string{"-context-policy", "unknown=strict"}))
+		assert.Error(t, fs.Parse([]string{"-context-policy", "return=lenient"}))
+	})
+
+	t.Run("policy resolution", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{
+			AllowEmptyReturns:      true,
+			AllowEmptyDeclarations: true,
+			ContextPolicies: map[LiteralContext]Policy{
+				ContextReturn:       PolicyStrict,
+				ContextCallArgument: PolicyAllowPartial,
+			},
+		}
+
+		require.NoError(t, config.Prepare())
+
+		assert.Equal(t, PolicyStrict, config.contextPolicy(ContextReturn))
+		assert.Equal(t, PolicyAllowEmpty, config.contextPolicy(ContextDeclaration))
+		assert.Equal(t, PolicyAllowEmpty, config.contextPolicy(ContextInterfaceAssertion))
+		assert.Equal(t, PolicyAllowPartial, config.contextPolicy(ContextCallArgument))
+		assert.Equal(t, PolicyStrict, config.contextPolicy(ContextMapValue))
+	})
+
+	t.Run("invalid context policies", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{ContextPolicies: map[LiteralContext]Policy{"unknown": PolicyStrict}}
+		assert.Error(t, config.Prepare())
+
+		config = Config{ContextPolicies: map[LiteralContext]Policy{ContextReturn: "lenient"}}
+		assert.Error(t, config.Prepare())
+	})
+}
+
+func TestConfig_CalleeRules(t *testing.T) {
+	t.Parallel()
+
+	t.Run("flag parsing callee rules", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{}
+		fs := config.BindToFlagSet(flag.NewFlagSet("test", flag.ContinueOnError))
+		fs.SetOutput(io.Discard)
+
+		args := []string{
+			"-callee-policy", `encoding/json\.Unmarshal:1=allow-empty`,
+			"-callee-policy", `\(\*database/sql\.DB\)\.Query:*=strict`,
+		}
+		err := fs.Parse(args)
+		require.NoError(t, err)
+
+		assert.Equal(t, []CalleeRule{
+			{CalleeRx: `encoding/json\.Unmarshal`, Arg: 1, Policy: PolicyAllowEmpty},
+			{CalleeRx: `\(\*database/sql\.DB\)\.Query`, Arg: AnyArg, Policy: PolicyStrict},
+		}, config.CalleeRules)
+		assert.Equal(t,
+			`encoding/json\.Unmarshal:1=allow-empty,\(\*database/sql\.DB\)\.Query:*=strict`,
+			fs.Lookup("callee-policy").Value.String(),
+		)
+
+		assert.Error(t, fs.Parse([]string{"-callee-policy", "fmt\\.Println"}))
+		assert.Error(t, fs.Parse([]string{"-callee-policy", "fmt\\.Println=strict"}))
+		assert.Error(t, fs.Parse([]string{"-callee-policy", "fmt\\.Println:first=strict"}))
+	})
+
+	t.Run("callee rule matching", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{
+			CalleeRules: []CalleeRule{
+				{CalleeRx: `encoding/json\.Unmarshal`, Arg: 1, Policy: PolicyAllowEmpty},
+				{CalleeRx: `errors\.As`, Arg: AnyArg, Policy: PolicyAllowPartial},
+			},
+		}
+
+		require.NoError(t, config.Prepare())
+
+		assert.True(t, config.CalleeRules[0].matches("encoding/json.Unmarshal", 1))
+		assert.False(t, config.CalleeRules[0].matches("encoding/json.Unmarshal", 0))
+		assert.True(t, config.CalleeRules[1].matches("errors.As", 0))
+		assert.True(t, config.CalleeRules[1].matches("errors.As", 1))
+		assert.False(t, config.CalleeRules[1].matches("errors.Is", 1))
+	})
+
+	t.Run("invalid callee rules", func(t *testing.T) {
+		t.Parallel()
+
+		for _, r := range []CalleeRule{
+			{CalleeRx: "[invalid", Arg: AnyArg, Policy: PolicyStrict},
+			{CalleeRx: ".*", Arg: AnyArg, Policy: "lenient"},
+			{CalleeRx: ".*", Arg: -2, Policy: PolicyStrict},
+		} {
+			config := Config{CalleeRules: []CalleeRule{r}}
+
+			err := config.Prepare()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "prepare callee rule")
+		}
+	})
+}
+
+func TestConfig_SiteRules(t *testing.T) {
+	t.Parallel()
+
+	t.Run("flag parsing site rules", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{}
+		fs := config.BindToFlagSet(flag.NewFlagSet("test", flag.ContinueOnError))
+		fs.SetOutput(io.Discard)
+
+		args := []string{
+			"-site-policy", `.*_test\.go:.*/pkg\.Request=allow-empty`,
+			"-site-policy", `.*/internal/testutil:.*=allow-partial`,
+			"-site-policy", `examples/.*:.*\.(?:Request|[[:alpha:]]+Response)=strict`,
+		}
+		err := fs.Parse(args)
+		require.NoError(t, err)
+
+		assert.Equal(t, []SiteRule{
+			{SiteRx: `.*_test\.go`, TypeRx: `.*/pkg\.Request`, Policy: PolicyAllowEmpty},
+			{SiteRx: `.*/internal/testutil`, TypeRx: `.*`, Policy: PolicyAllowPartial},
+			{SiteRx: `examples/.*`, TypeRx: `.*\.(?:Request|[[:alpha:]]+Response)`, Policy: PolicyStrict},
+		}, config.SiteRules)
+		assert.Equal(t,
+			`.*_test\.go:.*/pkg\.Request=allow-empty,.*/internal/testutil:.*=allow-partial,`+
+				`examples/.*:.*\.(?:Request|[[:alpha:]]+Response)=strict`,
+			fs.Lookup("site-policy").Value.String(),
+		)
+
+		assert.Error(t, fs.Parse([]string{"-site-policy", `.*_test\.go`}))
+		assert.Error(t, fs.Parse([]string{"-site-policy", `.*_test\.go=strict`}))
+	})
+
+	t.Run("site rule matching", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{
+			SiteRules: []SiteRule{
+				{SiteRx: `.*_test\.go`, TypeRx: `.*/pkg\.Request`, Policy: PolicyAllowEmpty},
+			},
+		}
+
+		require.NoError(t, config.Prepare())
+
+		r := config.SiteRules[0]
+		names := []string{"example.com/pkg.Request"}
+
+		assert.True(t, r.matches(literalSite{pkgPath: "example.com/app", filePath: "/src/app/app_test.go"}, names))
+		assert.False(t, r.matches(literalSite{pkgPath: "example.com/app", filePath: "/src/app/app.go"}, names))
+		assert.False(t, r.matches(literalSite{pkgPath: "example.com/app", filePath: "/src/app/app_test.go"},
+			[]string{"example.com/pkg.Response"}))
+	})
+
+	t.Run("invalid site rules", func(t *testing.T) {
+		t.Parallel()
+
+		for _, r := range []SiteRule{
+			{SiteRx: "[invalid", TypeRx: ".*", Policy: PolicyStrict},
+			{SiteRx: ".*", TypeRx: "[invalid", Policy: PolicyStrict},
+			{SiteRx: ".*", TypeRx: ".*", Policy: "lenient"},
+		} {
+			config := Config{SiteRules: []SiteRule{r}}
+
+			err := config.Prepare()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "prepare site rule")
+		}
+	})
+}
+
+func TestConfig_Severity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("flag parsing severity", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{}
+		fs := config.BindToFlagSet(flag.NewFlagSet("test", flag.ContinueOnError))
+		fs.SetOutput(io.Discard)
+
+		args := []string{
+			"-severity", "warning",
+			"-error-rx", ".*Payment.*",
+			"-warning-rx", ".*Warn.*",
+			"-info-rx", ".*Info.*",
+		}
+		err := fs.Parse(args)
+		require.NoError(t, err)
+
+		assert.Equal(t, SeverityWarning, config.Severity)
+		assert.Equal(t, []string{".*Payment.*"}, config.ErrorRx)
+		assert.Equal(t, []string{".*Warn.*"}, config.WarningRx)
+		assert.Equal(t, []string{".*Info.*"}, config.InfoRx)
+
+		err = fs.Parse([]string{"-severity", "fatal"})
+		assert.Error(t, err)
+	})
+
+	t.Run("severity resolution", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{
+			ErrorRx:   []string{".*Payment.*"},
+			WarningRx: []string{".*Payment.*", ".*Warn.*"},
+			InfoRx:    []string{".*Warn.*", ".*Info.*"},
+		}
+
+		err := config.Prepare()
+		require.NoError(t, err)
+
+		assert.Equal(t, SeverityError, config.severityOf("pkg.PaymentRequest"))
+		assert.Equal(t, SeverityWarning, config.severityOf("pkg.WarnStruct"))
+		assert.Equal(t, SeverityInfo, config.severityOf("pkg.InfoStruct"))
+		assert.Equal(t, SeverityError, config.severityOf("pkg.RegularStruct"))
+
+		config.Severity = SeverityInfo
+		assert.Equal(t, SeverityInfo, config.severityOf("pkg.RegularStruct"))
+		assert.Equal(t, SeverityInfo, config.defaultSeverity())
+	})
+
+	t.Run("invalid severity", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{Severity: "fatal"}
+
+		err := config.Prepare()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "parse severity")
+	})
+
+	t.Run("invalid severity patterns", func(t *testing.T) {
+		t.Parallel()
+
+		for _, config := range []Config{
+			{ErrorRx: []string{"[invalid"}},
+			{WarningRx: []string{"[invalid"}},
+			{InfoRx: []string{"[invalid"}},
+		} {
+			err := config.Prepare()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "severity patterns")
+		}
+	})
+}
+
+func TestConfig_Thresholds(t *testing.T) {
+	t.Parallel()
+
+	t.Run("flag parsing thresholds", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{}
+		fs := config.BindToFlagSet(flag.NewFlagSet("test", flag.ContinueOnError))
+		fs.SetOutput(io.Discard)
+
+		args := []string{
+			"-threshold", ".*Options=max-missing:2",
+			"-threshold", ".*=Wide=min-set-percent:50",
+			"-threshold", ".*Any=non-empty",
+		}
+		err := fs.Parse(args)
+		require.NoError(t, err)
+
+		assert.Equal(t, []Threshold{
+			{TypeRx: ".*Options", Mode: ThresholdMaxMissing, Value: 2},
+			{TypeRx: ".*=Wide", Mode: ThresholdMinSetPercent, Value: 50},
+			{TypeRx: ".*Any", Mode: ThresholdNonEmpty},
+		}, config.Thresholds)
+
+		assert.Equal(t,
+			".*Options=max-missing:2,.*=Wide=min-set-percent:50,.*Any=non-empty",
+			fs.Lookup("threshold").Value.String(),
+		)
+
+		assert.Error(t, fs.Parse([]string{"-threshold", ".*Options"}))
+		assert.Error(t, fs.Parse([]string{"-threshold", ".*Options=max-missing:many"}))
+	})
+
+	t.Run("threshold resolution", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{
+			Thresholds: []Threshold{
+				{TypeRx: ".*Options", Mode: ThresholdMaxMissing, Value: 2},
+				{TypeRx: ".*", Mode: ThresholdNonEmpty},
+			},
+		}
+
+		err := config.Prepare()
+		require.NoError(t, err)
+
+		th, ok := config.thresholdOf("pkg.Options")
+		require.True(t, ok)
+		assert.Equal(t, ThresholdMaxMissing, th.Mode)
+
+		th, ok = config.thresholdOf("pkg.Other")
+		require.True(t, ok)
+		assert.Equal(t, ThresholdNonEmpty, th.Mode)
+	})
+
+	t.Run("threshold allowance", func(t *testing.T) {
+		t.Parallel()
+
+		maxMissing := Threshold{Mode: ThresholdMaxMissing, Value: 2}
+		assert.True(t, maxMissing.allows(2, 5, false))
+		assert.False(t, maxMissing.allows(3, 5, false))
+
+		minSet := Threshold{Mode: ThresholdMinSetPercent, Value: 50}
+		assert.True(t, minSet.allows(2, 4, false))
+		assert.False(t, minSet.allows(3, 4, false))
+
+		nonEmpty := Threshold{Mode: ThresholdNonEmpty}
+		assert.True(t, nonEmpty.allows(3, 4, false))
+		assert.False(t, nonEmpty.allows(4, 4, true))
+	})
+
+	t.Run("invalid thresholds", func(t *testing.T) {
+		t.Parallel()
+
+		for _, th := range []Threshold{
+			{TypeRx: "[invalid", Mode: ThresholdNonEmpty},
+			{TypeRx: ".*", Mode: "unknown"},
+			{TypeRx: ".*", Mode: ThresholdMaxMissing, Value: -1},
+			{TypeRx: ".*", Mode: ThresholdMinSetPercent, Value: 101},
+		} {
+			config := Config{Thresholds: []Threshold{th}}
+
+			err := config.Prepare()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "prepare threshold")
+		}
+	})
+}
+
 func TestStringSliceFlag(t *testing.T) {
 	t.Parallel()
 
@@ -199,6 +646,127 @@ func TestStringSliceFlag(t *testing.T) {
 	})
 }
 
+func TestConfig_Profiles(t *testing.T) {
+	t.Parallel()
+
+	profiles := map[string][]string{
+		"tests": {"allow-empty", "include-rx=.*\\.Test", "context-policy=return=allow-partial"},
+	}
+
+	t.Run("selected profile is applied", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{IncludeRx: []string{`.*\.Other`}, Profiles: profiles, Profile: "tests"}
+		require.NoError(t, config.Prepare())
+
+		cfg, err := config.withProfile()
+		require.NoError(t, err)
+
+		assert.True(t, cfg.AllowEmpty)
+		assert.Equal(t, []string{`.*\.Other`, `.*\.Test`}, cfg.IncludeRx)
+		assert.Equal(t, PolicyAllowPartial, cfg.contextPolicy(ContextReturn))
+		assert.Empty(t, cfg.Profile)
+		assert.Nil(t, cfg.Profiles)
+
+		// original config is left intact
+		assert.False(t, config.AllowEmpty)
+		assert.Equal(t, []string{`.*\.Other`}, config.IncludeRx)
+	})
+
+	t.Run("no profile selected", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{Profiles: profiles}
+		require.NoError(t, config.Prepare())
+
+		cfg, err := config.withProfile()
+		require.NoError(t, err)
+		assert.False(t, cfg.AllowEmpty)
+	})
+
+	t.Run("flags", func(t *testing.T) {
+		t.Parallel()
+
+		config := Config{}
+		fs := config.BindToFlagSet(flag.NewFlagSet("test", flag.ContinueOnError))
+		fs.SetOutput(io.Discard)
+
+		err := fs.Parse([]string{
+			"-profile-setting", "tests:allow-empty",
+			"-profile-setting", "tests:allow-empty-rx=.*Options",
+			"-profile", "tests",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "tests", config.Profile)
+		assert.Equal(t, map[string][]string{"tests": {"allow-empty", "allow-empty-rx=.*Options"}}, config.Profiles)
+		require.Error(t, fs.Set("profile-setting", "tests"))
+	})
+
+	t.Run("invalid profiles", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name   string
+			config Config
+			errMsg string
+		}{
+			{
+				name:   "unknown profile",
+				config: Config{Profiles: profiles, Profile: "unknown"},
+				errMsg: "unknown profile",
+			},
+			{
+				name:   "unknown setting",
+				config: Config{Profiles: map[string][]string{"tests": {"unknown"}}},
+				errMsg: "unknown setting",
+			},
+			{
+				name:   "invalid setting value",
+				config: Config{Profiles: map[string][]string{"tests": {"include-rx=[invalid"}}},
+				errMsg: "compile include patterns",
+			},
+			{
+				name:   "nested profile",
+				config: Config{Profiles: map[string][]string{"tests": {"profile=tests"}}},
+				errMsg: "setting is not allowed in profile",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := tt.config.Prepare()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			})
+		}
+	})
+}
+
+func TestConfig_clone(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		IncludeRx:       []string{`.*\.Test`},
+		ContextPolicies: map[LiteralContext]Policy{ContextReturn: PolicyAllowEmpty},
+		AllowEmpty:      true,
+	}
+
+	clone := config.clone()
+	assert.Equal(t, config, clone)
+
+	fs := clone.BindToFlagSet(flag.NewFlagSet("", flag.ContinueOnError))
+	require.NoError(t, fs.Set("include-rx", `.*\.Other`))
+	require.NoError(t, fs.Set("context-policy", "return=strict"))
+	require.NoError(t, fs.Set("allow-empty", "false"))
+
+	assert.Equal(t, []string{`.*\.Test`}, config.IncludeRx)
+	assert.Equal(t, map[LiteralContext]Policy{ContextReturn: PolicyAllowEmpty}, config.ContextPolicies)
+	assert.True(t, config.AllowEmpty)
+}
+
 func TestConfig_Integration(t *testing.T) {
 	t.Parallel()
 
@@ -362,3 +930,48 @@ func TestNewAnalyzer_ConfigPreservation(t *testing.T) {
 		assert.Contains(t, err.Error(), "compile include patterns")
 	})
 }
+
+func TestAnalyzer_prepare(t *testing.T) {
+	t.Parallel()
+
+	t.Run("flags set after creation are compiled", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAnalyzer(defaultName, Config{})
+		fs := a.config.BindToFlagSet(flag.NewFlagSet("", flag.ContinueOnError))
+
+		require.NoError(t, fs.Set("i", `.*\.Test`))
+		require.NoError(t, fs.Set("allow-empty-rx", `.*\.Options`))
+		require.NoError(t, a.prepare())
+
+		assert.True(t, a.config.includePatterns.MatchFullString("pkg.Test"))
+		assert.True(t, a.config.allowEmptyPatterns.MatchFullString("pkg.Options"))
+	})
+
+	t.Run("configuration is frozen after first preparation", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAnalyzer(defaultName, Config{})
+		fs := a.config.BindToFlagSet(flag.NewFlagSet("", flag.ContinueOnError))
+
+		require.NoError(t, a.prepare())
+		require.NoError(t, fs.Set("i", `[invalid`))
+		require.NoError(t, a.prepare())
+
+		assert.Empty(t, a.config.includePatterns)
+	})
+
+	t.Run("invalid flag values are reported", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAnalyzer(defaultName, Config{})
+		fs := a.config.BindToFlagSet(flag.NewFlagSet("", flag.ContinueOnError))
+
+		require.NoError(t, fs.Set("e", `[invalid`))
+
+		err := a.prepare()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "compile exclude patterns")
+		assert.Equal(t, err, a.prepare())
+	})
+}
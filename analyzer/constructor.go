@@ -0,0 +1,177 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+
+	"dev.gaijin.team/go/exhaustruct/v4/internal/comment"
+)
+
+// constructorFact is exported for types annotated with
+// `//exhaustruct:constructor` directive, so literals of such types can be
+// reported in other packages.
+type constructorFact struct {
+	Constructor string
+}
+
+func (*constructorFact) AFact() {}
+
+func (f *constructorFact) String() string {
+	return "constructor " + f.Constructor
+}
+
+// exportConstructorFacts exports facts for all types of the package annotated
+// with valid `//exhaustruct:constructor` directive.
+func exportConstructorFacts(pass *analysis.Pass) {
+	forEachConstructorDirective(pass, func(ts *ast.TypeSpec, d *ast.Comment) {
+		obj, fn, problem := resolveConstructor(pass, ts, d)
+		if problem == "" && obj != nil {
+			pass.ExportObjectFact(obj, &constructorFact{Constructor: fn.Name()})
+		}
+	})
+}
+
+// checkConstructorDirectives reports malformed `//exhaustruct:constructor`
+// directives of the package.
+func (a *analyzer) checkConstructorDirectives(pass *analysis.Pass, res *Result) {
+	forEachConstructorDirective(pass, func(ts *ast.TypeSpec, d *ast.Comment) {
+		if _, _, problem := resolveConstructor(pass, ts, d); problem != "" {
+			res.report(pass, newFinding(d.Pos(), RuleInvalidDirective, a.config.defaultSeverity(),
+				"%s directive %s", comment.DirectiveConstructor, problem))
+		}
+	})
+}
+
+// forEachConstructorDirective calls a given function for every
+// `//exhaustruct:constructor` directive attached to a type declaration of the
+// package.
+func forEachConstructorDirective(pass *analysis.Pass, fn func(ts *ast.TypeSpec, d *ast.Comment)) {
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec) //nolint:forcetypeassert
+
+				var docs []*ast.CommentGroup
+
+				for _, doc := range []*ast.CommentGroup{ts.Doc, gd.Doc} {
+					if doc != nil && (doc == ts.Doc || len(gd.Specs) == 1) {
+						docs = append(docs, doc)
+					}
+				}
+
+				for _, d := range comment.FindDirectives(docs, comment.DirectiveConstructor) {
+					fn(ts, d)
+				}
+			}
+		}
+	}
+}
+
+// resolveConstructor returns a type and its constructor named by a given
+// directive. In case directive is malformed, the problem is described by the
+// third return value.
+func resolveConstructor(pass *analysis.Pass, ts *ast.TypeSpec, d *ast.Comment) (*types.TypeName, *types.Func, string) {
+	args := comment.DirectiveArgs(d)
+	if len(args) != 1 {
+		return nil, nil, "requires a constructor function name"
+	}
+
+	obj, ok := pass.TypesInfo.Defs[ts.Name].(*types.TypeName)
+	if !ok {
+		return nil, nil, ""
+	}
+
+	fn, ok := pass.Pkg.Scope().Lookup(args[0]).(*types.Func)
+	if !ok {
+		return nil, nil, "names unknown function " + args[0]
+	}
+
+	if !isConstructorOf(fn, obj) {
+		return nil, nil, "names function " + args[0] + ", that does not return " +
+			obj.Name() + " or *" + obj.Name()
+	}
+
+	return obj, fn, ""
+}
+
+// getConstructor returns a name of constructor literal of a given type should
+// be replaced with. Constructor is either defined by `//exhaustruct:constructor`
+// directive, in case [Config.CheckConstructors] is enabled, or, in case
+// [Config.DetectConstructors] is enabled, detected for types with unexported
+// fields, which package exports `NewT` function returning `T` or `*T`.
+//
+// Literals are only reported outside of the type package.
+func (a *analyzer) getConstructor(pass *analysis.Pass, lit *ast.CompositeLit) (string, bool) {
+	named, ok := types.Unalias(pass.TypesInfo.TypeOf(lit)).(*types.Named)
+	if !ok {
+		return "", false
+	}
+
+	obj := named.Origin().Obj()
+	if obj.Pkg() == nil || obj.Pkg() == pass.Pkg {
+		return "", false
+	}
+
+	if ctor, ok := getPackageFacts(pass).constructors[obj]; ok && a.config.CheckConstructors {
+		return ctor, true
+	}
+
+	if !a.config.DetectConstructors || !hasUnexportedFields(named) {
+		return "", false
+	}
+
+	fn, ok := obj.Pkg().Scope().Lookup("New" + obj.Name()).(*types.Func)
+	if !ok || !fn.Exported() || !isConstructorOf(fn, obj) {
+		return "", false
+	}
+
+	return fn.Name(), true
+}
+
+// isConstructorOnly checks whether literal of a given type must be replaced
+// with constructor call, see [analyzer.getConstructor].
+func (a *analyzer) isConstructorOnly(pass *analysis.Pass, lit *ast.CompositeLit) bool {
+	_, ok := a.getConstructor(pass, lit)
+	return ok
+}
+
+// isConstructorOf checks whether the first result of a function is a given
+// type or a pointer to it.
+func isConstructorOf(fn *types.Func, obj *types.TypeName) bool {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() != nil || sig.Results().Len() == 0 {
+		return false
+	}
+
+	typ := types.Unalias(sig.Results().At(0).Type())
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = types.Unalias(ptr.Elem())
+	}
+
+	named, ok := typ.(*types.Named)
+
+	return ok && named.Origin().Obj() == obj
+}
+
+func hasUnexportedFields(named *types.Named) bool {
+	strct, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+
+	for i := range strct.NumFields() {
+		if !strct.Field(i).Exported() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,267 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+)
+
+// LiteralContext is a syntactic context composite literal is used in.
+type LiteralContext string
+
+const (
+	ContextOther              LiteralContext = "other"
+	ContextReturn             LiteralContext = "return"
+	ContextDeclaration        LiteralContext = "declaration"
+	ContextAssignment         LiteralContext = "assignment"
+	ContextCallArgument       LiteralContext = "call-argument"
+	ContextMapValue           LiteralContext = "map-value"
+	ContextSliceElement       LiteralContext = "slice-element"
+	ContextChannelSend        LiteralContext = "channel-send"
+	ContextFieldValue         LiteralContext = "field-value"
+	ContextComparison         LiteralContext = "comparison"
+	ContextInterfaceAssertion LiteralContext = "interface-assertion"
+)
+
+// literalContexts is a list of all contexts that can be configured.
+//
+//nolint:gochecknoglobals
+var literalContexts = []LiteralContext{
+	ContextReturn,
+	ContextDeclaration,
+	ContextAssignment,
+	ContextCallArgument,
+	ContextMapValue,
+	ContextSliceElement,
+	ContextChannelSend,
+	ContextFieldValue,
+	ContextComparison,
+	ContextInterfaceAssertion,
+}
+
+// Policy defines how incomplete literals are treated.
+type Policy string
+
+const (
+	// PolicyStrict requires all fields to be initialized.
+	PolicyStrict Policy = "strict"
+
+	// PolicyAllowEmpty allows literals without any field initialized.
+	PolicyAllowEmpty Policy = "allow-empty"
+
+	// PolicyAllowPartial allows any incomplete literals, including empty ones.
+	PolicyAllowPartial Policy = "allow-partial"
+)
+
+// ParsePolicy converts a string into [Policy].
+func ParsePolicy(s string) (Policy, error) {
+	switch p := Policy(s); p {
+	case PolicyStrict, PolicyAllowEmpty, PolicyAllowPartial:
+		return p, nil
+	default:
+		return "", e.New("unknown policy", fields.F("policy", s))
+	}
+}
+
+// allowsEmpty reports whether the policy allows empty literals.
+func (p Policy) allowsEmpty() bool {
+	return p == PolicyAllowEmpty || p == PolicyAllowPartial
+}
+
+// allowsPartial reports whether the policy allows partially initialized
+// literals.
+func (p Policy) allowsPartial() bool {
+	return p == PolicyAllowPartial
+}
+
+// ParseContextPolicy parses context policy from string in form of
+// `<context>=<policy>`, e.g. `call-argument=allow-empty`.
+func ParseContextPolicy(str string) (LiteralContext, Policy, error) {
+	ctx, policy, ok := strings.Cut(str, "=")
+	if !ok {
+		return "", "", e.New("context policy must be in form of <context>=<policy>",
+			fields.F("context-policy", str))
+	}
+
+	if !isKnownContext(LiteralContext(ctx)) {
+		return "", "", e.New("unknown context", fields.F("context", ctx))
+	}
+
+	p, err := ParsePolicy(policy)
+	if err != nil {
+		return "", "", err
+	}
+
+	return LiteralContext(ctx), p, nil
+}
+
+func isKnownContext(ctx LiteralContext) bool {
+	for _, c := range literalContexts {
+		if c == ctx {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contextPoliciesFlag implements flag.Value interface for
+// map[LiteralContext]Policy fields.
+type contextPoliciesFlag struct {
+	m *map[LiteralContext]Policy
+}
+
+func (f contextPoliciesFlag) String() string {
+	if f.m == nil {
+		return ""
+	}
+
+	s := make([]string, 0, len(*f.m))
+	for ctx, p := range *f.m {
+		s = append(s, string(ctx)+"="+string(p))
+	}
+
+	sort.Strings(s)
+
+	return strings.Join(s, ",")
+}
+
+func (f contextPoliciesFlag) Set(value string) error {
+	ctx, p, err := ParseContextPolicy(value)
+	if err != nil {
+		return err
+	}
+
+	if *f.m == nil {
+		*f.m = make(map[LiteralContext]Policy)
+	}
+
+	(*f.m)[ctx] = p
+
+	return nil
+}
+
+// literalContext describes where composite literal is used.
+type literalContext struct {
+	Kind LiteralContext
+
+	// Node is the node that defines the context, e.g. [ast.ReturnStmt] for
+	// [ContextReturn].
+	Node ast.Node
+}
+
+// getLiteralContext classifies syntactic context of the composite literal at
+// the top of the stack. Pointer taking (&) and parentheses are skipped while
+// going up the stack, so `&T{}` and `(T{})` share the context of the
+// expression they are part of.
+//
+//revive:disable-next-line:cyclomatic
+func getLiteralContext(info *types.Info, stack []ast.Node) literalContext {
+	child := stack[len(stack)-1]
+
+	for i := len(stack) - 1; i > 0; i-- {
+		parent := stack[i-1]
+
+		switch p := parent.(type) {
+		case *ast.UnaryExpr:
+			// Only allow pointer taking (&)
+			if p.Op == token.AND {
+				child = p
+				continue
+			}
+
+		case *ast.ParenExpr:
+			child = p
+			continue
+
+		case *ast.ReturnStmt:
+			return literalContext{Kind: ContextReturn, Node: p}
+
+		case *ast.AssignStmt:
+			if p.Tok == token.DEFINE {
+				return literalContext{Kind: ContextDeclaration, Node: p}
+			}
+
+			return literalContext{Kind: ContextAssignment, Node: p}
+
+		case *ast.ValueSpec:
+			if isBlankInterfaceSpec(info, p) {
+				return literalContext{Kind: ContextInterfaceAssertion, Node: p}
+			}
+
+			return literalContext{Kind: ContextDeclaration, Node: p}
+
+		case *ast.CallExpr:
+			if p.Fun != child {
+				return literalContext{Kind: ContextCallArgument, Node: p}
+			}
+
+		case *ast.SendStmt:
+			if p.Value == child {
+				return literalContext{Kind: ContextChannelSend, Node: p}
+			}
+
+		case *ast.BinaryExpr:
+			if p.Op == token.EQL || p.Op == token.NEQ {
+				return literalContext{Kind: ContextComparison, Node: p}
+			}
+
+		case *ast.KeyValueExpr:
+			if p.Value == child && i > 1 {
+				if outer, ok := stack[i-2].(*ast.CompositeLit); ok {
+					return literalContext{Kind: elementContext(info, outer), Node: outer}
+				}
+			}
+
+		case *ast.CompositeLit:
+			return literalContext{Kind: elementContext(info, p), Node: p}
+		}
+
+		return literalContext{Kind: ContextOther, Node: parent}
+	}
+
+	return literalContext{Kind: ContextOther, Node: nil}
+}
+
+// elementContext returns the context of an element of a given composite
+// literal.
+func elementContext(info *types.Info, lit *ast.CompositeLit) LiteralContext {
+	typ := info.TypeOf(lit)
+	if typ == nil {
+		return ContextOther
+	}
+
+	switch typ.Underlying().(type) {
+	case *types.Map:
+		return ContextMapValue
+	case *types.Slice, *types.Array:
+		return ContextSliceElement
+	case *types.Struct:
+		return ContextFieldValue
+	default:
+		return ContextOther
+	}
+}
+
+// isBlankInterfaceSpec checks whether value spec is a compile-time interface
+// assertion, like `var _ Iface = T{}`.
+func isBlankInterfaceSpec(info *types.Info, spec *ast.ValueSpec) bool {
+	if spec.Type == nil {
+		return false
+	}
+
+	for _, name := range spec.Names {
+		if name.Name != "_" {
+			return false
+		}
+	}
+
+	typ := info.TypeOf(spec.Type)
+
+	return typ != nil && types.IsInterface(typ)
+}
@@ -0,0 +1,223 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// varReturn is a return statement a local variable is returned by.
+type varReturn struct {
+	stmt *ast.ReturnStmt
+
+	// result is the expression variable is returned as, either the variable
+	// identifier itself or pointer taking of it.
+	result ast.Expr
+}
+
+// isReturnedOnlyOnFailure checks whether the literal is assigned to a local
+// variable, which only reaches failure return statements, e.g.:
+//
+//	out := Result{}
+//	if err != nil {
+//		return out, err
+//	}
+//
+// Failure returns are comma-ok and failure sentinel returns, and, in case
+// withErrors is true, error returns as well.
+func (a *analyzer) isReturnedOnlyOnFailure(
+	pass *analysis.Pass,
+	stack []ast.Node,
+	lc literalContext,
+	withErrors bool,
+) bool {
+	returns, ok := getLocalVarReturns(pass, stack, lc)
+	if !ok || len(returns) == 0 {
+		return false
+	}
+
+	for _, r := range returns {
+		if withErrors && isErrorReturnStatement(pass, r.stmt, r.result) {
+			continue
+		}
+
+		if a.isFailureReturnStatement(pass, stack, r.stmt, r.result) {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// getLocalVarReturns traces a composite literal on top of the stack, assigned
+// to a local variable, to return statements the variable is returned by.
+//
+// The second return value is false in case literal is not assigned to a local
+// variable, or variable is used in any other way, except of field access,
+// reassignment and being directly returned. Method calls are treated as
+// escaping, since methods with pointer receiver might leak the value.
+func getLocalVarReturns(pass *analysis.Pass, stack []ast.Node, lc literalContext) ([]varReturn, bool) {
+	obj, ok := getAssignedLocalVar(pass.TypesInfo, stack, lc)
+	if !ok {
+		return nil, false
+	}
+
+	body, ok := getEnclosingFuncBody(stack)
+	if !ok {
+		return nil, false
+	}
+
+	var (
+		returns []varReturn
+		escapes bool
+		path    []ast.Node
+	)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			path = path[:len(path)-1]
+			return false
+		}
+
+		if id, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[id] == obj {
+			r, ok := classifyVarUse(pass.TypesInfo, id, path)
+
+			switch {
+			case !ok:
+				escapes = true
+			case r != nil:
+				returns = append(returns, *r)
+			}
+		}
+
+		path = append(path, n)
+
+		return !escapes
+	})
+
+	if escapes {
+		return nil, false
+	}
+
+	return returns, true
+}
+
+// classifyVarUse classifies a use of a variable by its path in AST. In case
+// variable is returned, the return is provided. The second return value is
+// false in case the value of variable escapes.
+func classifyVarUse(info *types.Info, id *ast.Ident, path []ast.Node) (*varReturn, bool) {
+	// variables captured by closures might be used in any way
+	for _, n := range path[1:] {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return nil, false
+		}
+	}
+
+	var child ast.Expr = id
+
+	for i := len(path) - 1; i >= 0; i-- {
+		switch p := path[i].(type) {
+		case *ast.SelectorExpr:
+			// field access or field assignment, methods might leak the value
+			sel, ok := info.Selections[p]
+
+			return nil, p.X == child && ok && sel.Kind() == types.FieldVal
+
+		case *ast.AssignStmt:
+			// reassignment of the variable itself
+			for _, lhs := range p.Lhs {
+				if lhs == child {
+					return nil, child == id
+				}
+			}
+
+			return nil, false
+
+		case *ast.UnaryExpr:
+			if p.Op != token.AND || child != id {
+				return nil, false
+			}
+
+			child = p
+
+		case *ast.ReturnStmt:
+			return &varReturn{stmt: p, result: child}, true
+
+		default:
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+// getAssignedLocalVar returns a local variable the literal is assigned to, in
+// case literal is used in declaration or assignment context.
+func getAssignedLocalVar(info *types.Info, stack []ast.Node, lc literalContext) (*types.Var, bool) {
+	if lc.Kind != ContextDeclaration && lc.Kind != ContextAssignment {
+		return nil, false
+	}
+
+	// find the expression that is direct child of context node
+	var expr ast.Node
+
+	for i := len(stack) - 1; i > 0; i-- {
+		if stack[i-1] == lc.Node {
+			expr = stack[i]
+			break
+		}
+	}
+
+	var ident *ast.Ident
+
+	switch p := lc.Node.(type) {
+	case *ast.AssignStmt:
+		if len(p.Lhs) != len(p.Rhs) {
+			return nil, false
+		}
+
+		for i := range p.Rhs {
+			if p.Rhs[i] == expr {
+				ident, _ = p.Lhs[i].(*ast.Ident)
+			}
+		}
+
+	case *ast.ValueSpec:
+		for i := range p.Values {
+			if p.Values[i] == expr && i < len(p.Names) {
+				ident = p.Names[i]
+			}
+		}
+	}
+
+	if ident == nil || ident.Name == "_" {
+		return nil, false
+	}
+
+	v, ok := info.ObjectOf(ident).(*types.Var)
+	if !ok || v.Pkg() == nil || v.Parent() == nil || v.Parent() == v.Pkg().Scope() {
+		return nil, false
+	}
+
+	return v, true
+}
+
+// getEnclosingFuncBody returns a body of the innermost function (declaration
+// or literal) the top of the stack belongs to.
+func getEnclosingFuncBody(stack []ast.Node) (*ast.BlockStmt, bool) {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncLit:
+			return fn.Body, fn.Body != nil
+
+		case *ast.FuncDecl:
+			return fn.Body, fn.Body != nil
+		}
+	}
+
+	return nil, false
+}
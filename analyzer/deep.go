@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"dev.gaijin.team/go/exhaustruct/v4/internal/structure"
+)
+
+// deepLiteral is a checked structure literal, nested literals of which are
+// checked as its parts in deep mode, see [Config.Deep]. Only value struct
+// fields are descended into, and structure can not contain itself by value,
+// so paths are always finite.
+type deepLiteral struct {
+	// path is a path of the literal from the outermost checked literal, e.g.
+	// `pkg.Server.TLS`.
+	path string
+}
+
+// nested returns a deep literal of a field with a given name.
+func (d deepLiteral) nested(field string) deepLiteral {
+	return deepLiteral{path: d.path + "." + field}
+}
+
+// getDeepParent returns a deep literal of the composite literal on top of the
+// stack, in case it is a value of value struct field of already checked
+// literal. Both keyed and unkeyed parent literals are supported.
+func getDeepParent(pass *analysis.Pass, stack []ast.Node, checked map[*ast.CompositeLit]deepLiteral) (deepLiteral, bool) {
+	i := len(stack) - 1
+	for i > 0 {
+		if _, ok := stack[i-1].(*ast.ParenExpr); !ok {
+			break
+		}
+
+		i--
+	}
+
+	if i == 0 {
+		return deepLiteral{}, false //nolint:exhaustruct
+	}
+
+	var (
+		parent *ast.CompositeLit
+		field  string
+	)
+
+	switch p := stack[i-1].(type) {
+	case *ast.KeyValueExpr:
+		key, ok := p.Key.(*ast.Ident)
+		if !ok || p.Value != stack[i] || i < 2 {
+			return deepLiteral{}, false //nolint:exhaustruct
+		}
+
+		parent, _ = stack[i-2].(*ast.CompositeLit)
+		field = key.Name
+
+	case *ast.CompositeLit:
+		for idx, elt := range p.Elts {
+			if elt != stack[i] {
+				continue
+			}
+
+			if st, ok := pass.TypesInfo.TypeOf(p).Underlying().(*types.Struct); ok && idx < st.NumFields() {
+				parent = p
+				field = st.Field(idx).Name()
+			}
+		}
+	}
+
+	if parent == nil {
+		return deepLiteral{}, false //nolint:exhaustruct
+	}
+
+	d, ok := checked[parent]
+	if !ok || !isValueStructField(pass.TypesInfo.TypeOf(parent), field) {
+		return deepLiteral{}, false //nolint:exhaustruct
+	}
+
+	return d.nested(field), true
+}
+
+// isValueStructField checks whether a field with a given name of a structure
+// type is of value (non-pointer, non-interface) struct type.
+func isValueStructField(typ types.Type, name string) bool {
+	st, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+
+	for i := range st.NumFields() {
+		if f := st.Field(i); f.Name() == name {
+			_, ok = f.Type().Underlying().(*types.Struct)
+			return ok
+		}
+	}
+
+	return false
+}
+
+// deepMissingMessage returns a message listing paths of missing fields of a
+// nested literal, e.g. `pkg.Server.TLS.CertFile is missing`.
+func deepMissingMessage(path string, missing structure.Fields) string {
+	paths := make([]string, 0, len(missing))
+	for _, f := range missing {
+		paths = append(paths, path+"."+f.Name)
+	}
+
+	if len(paths) == 1 {
+		return paths[0] + " is missing"
+	}
+
+	return strings.Join(paths, ", ") + " are missing"
+}
@@ -0,0 +1,201 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// FieldRequirement defines whether a field has to be initialized in literals.
+type FieldRequirement string
+
+const (
+	// FieldRequired fields must be initialized in every literal.
+	FieldRequired FieldRequirement = "required"
+
+	// FieldOptional fields are marked with `exhaustruct:"optional"` tag, or
+	// are embedded fields not required by [Config.Embedded] policy, and may be
+	// omitted.
+	FieldOptional FieldRequirement = "optional"
+
+	// FieldPackageRequired fields are unexported, so they must be initialized
+	// only in literals within the package structure is declared in.
+	FieldPackageRequired FieldRequirement = "package-only"
+
+	// FieldForbidden fields are marked with `exhaustruct:"forbidden"` tag, or
+	// are deprecated while [Config.CheckDeprecated] is enabled, and must not be
+	// set in literals.
+	FieldForbidden FieldRequirement = "forbidden"
+)
+
+// TypeDoc describes requirements of a structure type under type-level
+// configuration, see [Describe].
+type TypeDoc struct {
+	// Name is the full type name, including package path.
+	Name string `json:"name"`
+
+	// Checked is false in case the type is excluded from processing by
+	// include and exclude patterns.
+	Checked bool `json:"checked"`
+
+	// Rules is a list of type-level configuration rules applied to the type,
+	// e.g. `allow-empty` or `severity=warning`.
+	Rules []string `json:"rules,omitempty"`
+
+	// Directives is a list of directive comments attached to the type
+	// declaration.
+	Directives []string `json:"directives,omitempty"`
+
+	Fields []FieldDoc `json:"fields"`
+}
+
+// FieldDoc describes a single field of a structure.
+type FieldDoc struct {
+	Name        string           `json:"name"`
+	Type        string           `json:"type"`
+	Embedded    bool             `json:"embedded,omitempty"`
+	Requirement FieldRequirement `json:"requirement"`
+
+	// NonZero is true for fields that must not be set to constant zero value.
+	NonZero bool `json:"nonzero,omitempty"`
+}
+
+// Describe describes all package-level structure types declared in given files
+// of a package under type-level configuration, i.e. rules depending only on
+// the type itself. Settings depending on the place literal is written in, such
+// as `//exhaustruct:config` directives, scope, site filters, site and context
+// policies, are not reflected. Types are listed in order they are declared.
+func Describe(config Config, pkg *types.Package, files []*ast.File) ([]TypeDoc, error) {
+	cfg, err := config.withProfile()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cfg.Prepare(); err != nil {
+		return nil, err
+	}
+
+	a := newAnalyzer(defaultName, cfg)
+	docs := make([]TypeDoc, 0)
+
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec) //nolint:forcetypeassert
+
+				obj, ok := pkg.Scope().Lookup(ts.Name.Name).(*types.TypeName)
+				if !ok || obj.IsAlias() {
+					continue
+				}
+
+				strct, ok := obj.Type().Underlying().(*types.Struct)
+				if !ok {
+					continue
+				}
+
+				docs = append(docs, a.describeType(obj, strct, gd, ts))
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+// describeType describes a given structure type, ts is its declaration.
+func (a *analyzer) describeType(obj *types.TypeName, strct *types.Struct, gd *ast.GenDecl, ts *ast.TypeSpec) TypeDoc {
+	info := TypeInfo{
+		Name:        obj.Name(),
+		PackageName: obj.Pkg().Name(),
+		PackagePath: obj.Pkg().Path(),
+	}
+	name := info.String()
+
+	doc := TypeDoc{
+		Name:       name,
+		Checked:    a.shouldProcessType(&info),
+		Rules:      a.describeRules(name),
+		Directives: nil,
+		Fields:     make([]FieldDoc, 0, strct.NumFields()),
+	}
+
+	for _, cg := range []*ast.CommentGroup{gd.Doc, ts.Doc} {
+		if cg == nil || (cg == gd.Doc && len(gd.Specs) > 1) {
+			continue
+		}
+
+		for _, c := range cg.List {
+			if strings.HasPrefix(c.Text, "//exhaustruct:") {
+				doc.Directives = append(doc.Directives, c.Text)
+			}
+		}
+	}
+
+	deprecated := make(map[string]bool)
+
+	if st, ok := ts.Type.(*ast.StructType); ok && a.config.CheckDeprecated {
+		for _, field := range st.Fields.List {
+			for _, ident := range fieldIdents(field) {
+				deprecated[ident.Name] = isDeprecatedField(field)
+			}
+		}
+	}
+
+	for i, f := range a.structFields.Get(strct) {
+		req := FieldRequired
+
+		switch {
+		case f.Forbidden || deprecated[f.Name]:
+			req = FieldForbidden
+		case f.Optional, f.Embedded && !a.isEmbeddedRequired(obj.Pkg(), strct.Field(i).Type()):
+			req = FieldOptional
+		case !f.Exported:
+			req = FieldPackageRequired
+		}
+
+		doc.Fields = append(doc.Fields, FieldDoc{
+			Name:        f.Name,
+			Type:        types.TypeString(strct.Field(i).Type(), types.RelativeTo(obj.Pkg())),
+			Embedded:    strct.Field(i).Embedded(),
+			Requirement: req,
+			NonZero:     a.isNonZeroField(&info, f),
+		})
+	}
+
+	return doc
+}
+
+// describeRules returns a list of configuration rules applied to a type with
+// a given name.
+func (a *analyzer) describeRules(name string) []string {
+	var rules []string
+
+	if a.config.AllowEmpty || a.config.allowEmptyPatterns.MatchFullString(name) {
+		rules = append(rules, "allow-empty")
+	}
+
+	if t, ok := a.config.thresholdOf(name); ok {
+		rule := "threshold=" + string(t.Mode)
+		if t.Mode != ThresholdNonEmpty {
+			rule += ":" + strconv.Itoa(t.Value)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if a.config.failurePatterns.MatchFullString(name) {
+		rules = append(rules, "failure-sentinel")
+	}
+
+	if a.config.generatedIncludePatterns.MatchFullString(name) {
+		rules = append(rules, "generated-include")
+	}
+
+	return append(rules, "severity="+string(a.config.severityOf(name)))
+}
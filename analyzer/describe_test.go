@@ -0,0 +1,106 @@
+package analyzer_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+const describeSrc = `package p
+
+type Base struct {
+	ID int
+}
+
+// Config is a configuration.
+//
+//exhaustruct:gen
+type Config struct {
+	Base
+
+	Name    string
+	Timeout int ` + "`exhaustruct:\"optional\"`" + `
+	secret  string
+
+	// Deprecated: use Name instead.
+	Title string
+}
+
+type (
+	Options struct {
+		Filter func(string) bool
+	}
+
+	Alias = Options
+	ID    int
+)
+`
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+
+	f, err := parser.ParseFile(fset, "p.go", describeSrc, parser.ParseComments)
+	require.NoError(t, err)
+
+	pkg, err := (&types.Config{}).Check("example.com/p", fset, []*ast.File{f}, nil) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	docs, err := analyzer.Describe(analyzer.Config{
+		CheckDeprecated: true,
+		ExcludeRx:       []string{`.*\.Base`},
+		AllowEmptyRx:    []string{`.*\.Options`},
+		WarningRx:       []string{`.*\.Config`},
+		Thresholds:      []analyzer.Threshold{{TypeRx: `.*\.Options`, Mode: analyzer.ThresholdNonEmpty}},
+	}, pkg, []*ast.File{f})
+	require.NoError(t, err)
+
+	assert.Equal(t, []analyzer.TypeDoc{
+		{
+			Name:       "example.com/p.Base",
+			Checked:    false,
+			Rules:      []string{"severity=error"},
+			Directives: nil,
+			Fields: []analyzer.FieldDoc{
+				{Name: "ID", Type: "int", Embedded: false, Requirement: analyzer.FieldRequired},
+			},
+		},
+		{
+			Name:       "example.com/p.Config",
+			Checked:    true,
+			Rules:      []string{"severity=warning"},
+			Directives: []string{"//exhaustruct:gen"},
+			Fields: []analyzer.FieldDoc{
+				{Name: "Base", Type: "Base", Embedded: true, Requirement: analyzer.FieldRequired},
+				{Name: "Name", Type: "string", Embedded: false, Requirement: analyzer.FieldRequired},
+				{Name: "Timeout", Type: "int", Embedded: false, Requirement: analyzer.FieldOptional},
+				{Name: "secret", Type: "string", Embedded: false, Requirement: analyzer.FieldPackageRequired},
+				{Name: "Title", Type: "string", Embedded: false, Requirement: analyzer.FieldForbidden},
+			},
+		},
+		{
+			Name:       "example.com/p.Options",
+			Checked:    true,
+			Rules:      []string{"allow-empty", "threshold=non-empty", "severity=error"},
+			Directives: nil,
+			Fields: []analyzer.FieldDoc{
+				{Name: "Filter", Type: "func(string) bool", Embedded: false, Requirement: analyzer.FieldRequired},
+			},
+		},
+	}, docs)
+}
+
+func TestDescribe_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := analyzer.Describe(analyzer.Config{IncludeRx: []string{"[invalid"}}, types.NewPackage("p", "p"), nil)
+	require.Error(t, err)
+}
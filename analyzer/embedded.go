@@ -0,0 +1,170 @@
+package analyzer
+
+import (
+	"go/types"
+	"strings"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+	"golang.org/x/tools/go/analysis"
+
+	"dev.gaijin.team/go/exhaustruct/v4/internal/structure"
+)
+
+// EmbeddedPolicy defines whether embedded fields have to be initialized in
+// literals.
+type EmbeddedPolicy string
+
+const (
+	// EmbeddedRequired requires embedded fields same as any other field.
+	EmbeddedRequired EmbeddedPolicy = "required"
+
+	// EmbeddedOptional treats all embedded fields as optional.
+	EmbeddedOptional EmbeddedPolicy = "optional"
+
+	// EmbeddedIfRequired requires embedded fields only in case embedded
+	// structure has required fields itself. Embedded interfaces and other
+	// non-structure types are treated as optional.
+	EmbeddedIfRequired EmbeddedPolicy = "if-required"
+)
+
+// ParseEmbeddedPolicy converts a string into [EmbeddedPolicy]. Empty string is
+// treated as [EmbeddedRequired].
+func ParseEmbeddedPolicy(s string) (EmbeddedPolicy, error) {
+	switch p := EmbeddedPolicy(s); p {
+	case "":
+		return EmbeddedRequired, nil
+	case EmbeddedRequired, EmbeddedOptional, EmbeddedIfRequired:
+		return p, nil
+	default:
+		return "", e.New("unknown embedded policy", fields.F("embedded", s))
+	}
+}
+
+// Set implements [flag.Value] interface.
+func (p *EmbeddedPolicy) Set(value string) error {
+	v, err := ParseEmbeddedPolicy(value)
+	if err != nil {
+		return err
+	}
+
+	*p = v
+
+	return nil
+}
+
+// String implements [flag.Value] interface.
+func (p *EmbeddedPolicy) String() string {
+	if p == nil {
+		return ""
+	}
+
+	return string(*p)
+}
+
+// filterRequired removes fields, that are not required by [Config.Embedded]
+// policy or are deprecated, from a given list of fields of a structure.
+func (a *analyzer) filterRequired(pass *analysis.Pass, strct *types.Struct, sf structure.Fields) structure.Fields {
+	return a.removeDeprecated(pass, strct, a.applyEmbeddedPolicy(pass.Pkg, strct, sf))
+}
+
+// applyEmbeddedPolicy removes embedded fields, that are not required by
+// [Config.Embedded] policy, from a given list of fields of a structure. Pkg is
+// the package literal is located in.
+func (a *analyzer) applyEmbeddedPolicy(pkg *types.Package, strct *types.Struct, sf structure.Fields) structure.Fields {
+	if a.config.Embedded == EmbeddedRequired || a.config.Embedded == "" {
+		return sf
+	}
+
+	res := make(structure.Fields, 0, len(sf))
+
+	for _, f := range sf {
+		if !f.Embedded || a.isEmbeddedRequired(pkg, embeddedFieldType(strct, f.Name)) {
+			res = append(res, f)
+		}
+	}
+
+	if len(res) == 0 {
+		return nil
+	}
+
+	return res
+}
+
+// isEmbeddedRequired checks whether an embedded field of a given type is
+// required by [Config.Embedded] policy.
+func (a *analyzer) isEmbeddedRequired(pkg *types.Package, typ types.Type) bool {
+	switch a.config.Embedded {
+	case EmbeddedOptional:
+		return false
+	case EmbeddedIfRequired:
+		if ptr, ok := types.Unalias(typ).(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+
+		strct, ok := typ.Underlying().(*types.Struct)
+		if !ok {
+			return false
+		}
+
+		named, ok := types.Unalias(typ).(*types.Named)
+		onlyExported := ok && named.Obj().Pkg() != pkg
+
+		return len(a.structFields.Get(strct).Required(onlyExported)) > 0
+	default:
+		return true
+	}
+}
+
+// embeddedFieldType returns a type of a field with a given name.
+func embeddedFieldType(strct *types.Struct, name string) types.Type {
+	if f := fieldByName(strct, name); f != nil {
+		return f.Type()
+	}
+
+	return types.Typ[types.Invalid]
+}
+
+// fieldByName returns a field of a structure with a given name, or nil if
+// there is no such field.
+func fieldByName(strct *types.Struct, name string) *types.Var {
+	for i := range strct.NumFields() {
+		if f := strct.Field(i); f.Name() == name {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// missingFieldsMessage describes a list of missing fields, labeling embedded
+// ones along with their types, e.g. `fields A, B, embedded field Base
+// (pkg.Base)`.
+func missingFieldsMessage(strct *types.Struct, missing structure.Fields) string {
+	var regular, embedded []string
+
+	for _, f := range missing {
+		if !f.Embedded {
+			regular = append(regular, f.Name)
+			continue
+		}
+
+		typ := types.TypeString(embeddedFieldType(strct, f.Name), func(p *types.Package) string {
+			return p.Name()
+		})
+
+		embedded = append(embedded, f.Name+" ("+typ+")")
+	}
+
+	var parts []string
+
+	if len(regular) > 0 {
+		parts = append(parts, pluralizeField(len(regular))+" "+strings.Join(regular, ", "))
+	}
+
+	if len(embedded) > 0 {
+		parts = append(parts, "embedded "+pluralizeField(len(embedded))+" "+strings.Join(embedded, ", "))
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,67 @@
+package analyzer
+
+import (
+	"go/types"
+	"reflect"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// factsAnalyzer exports facts about declarations of every package, including
+// dependencies, so they can be used while checking literals of other packages.
+// It never checks literals, which keeps running it over the whole dependency
+// graph cheap, and it is only required by analyzers having features relying
+// on facts enabled, see [Config.needsFacts].
+//
+//nolint:gochecknoglobals
+var factsAnalyzer = &analysis.Analyzer{ //nolint:exhaustruct
+	Name:       "exhaustruct_facts",
+	Doc:        "Collects facts about structures declarations for exhaustruct",
+	Run:        runFacts,
+	FactTypes:  []analysis.Fact{new(constructorFact), new(deprecatedFact)},
+	ResultType: reflect.TypeOf((*packageFacts)(nil)),
+}
+
+// packageFacts holds facts of a package and all its dependencies.
+type packageFacts struct {
+	// constructors maps constructor-only types to their constructor names.
+	constructors map[*types.TypeName]string
+
+	// deprecated is a set of deprecated structure fields.
+	deprecated map[*types.Var]bool
+}
+
+func runFacts(pass *analysis.Pass) (any, error) {
+	exportConstructorFacts(pass)
+	exportDeprecatedFacts(pass)
+
+	res := &packageFacts{
+		constructors: make(map[*types.TypeName]string),
+		deprecated:   make(map[*types.Var]bool),
+	}
+
+	for _, f := range pass.AllObjectFacts() {
+		switch fact := f.Fact.(type) {
+		case *constructorFact:
+			if obj, ok := f.Object.(*types.TypeName); ok {
+				res.constructors[obj] = fact.Constructor
+			}
+		case *deprecatedFact:
+			if v, ok := f.Object.(*types.Var); ok {
+				res.deprecated[v] = true
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// getPackageFacts returns facts of the package being analyzed and all its
+// dependencies. In case facts are not collected, the result is empty.
+func getPackageFacts(pass *analysis.Pass) *packageFacts {
+	if res, ok := pass.ResultOf[factsAnalyzer].(*packageFacts); ok {
+		return res
+	}
+
+	return &packageFacts{constructors: nil, deprecated: nil}
+}
@@ -0,0 +1,172 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"dev.gaijin.team/go/exhaustruct/v4/internal/structure"
+)
+
+// deprecatedFact is exported for structure fields documented with
+// `Deprecated:` paragraph, so literals setting such fields can be reported in
+// other packages.
+type deprecatedFact struct{}
+
+func (*deprecatedFact) AFact() {}
+
+func (*deprecatedFact) String() string {
+	return "deprecated"
+}
+
+// exportDeprecatedFacts exports facts for all deprecated fields of structures
+// declared in the package.
+func exportDeprecatedFacts(pass *analysis.Pass) {
+	for _, f := range pass.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			st, ok := n.(*ast.StructType)
+			if !ok {
+				return true
+			}
+
+			for _, field := range st.Fields.List {
+				if !isDeprecatedField(field) {
+					continue
+				}
+
+				for _, ident := range fieldIdents(field) {
+					if v, ok := pass.TypesInfo.Defs[ident].(*types.Var); ok {
+						pass.ExportObjectFact(v, &deprecatedFact{})
+					}
+				}
+			}
+
+			return true
+		})
+	}
+}
+
+// isDeprecatedField checks whether field documentation, either doc comment or
+// line comment, contains a paragraph starting with `Deprecated: `.
+func isDeprecatedField(field *ast.Field) bool {
+	for _, cg := range []*ast.CommentGroup{field.Doc, field.Comment} {
+		if cg == nil {
+			continue
+		}
+
+		for _, p := range strings.Split(cg.Text(), "\n\n") {
+			if strings.HasPrefix(p, "Deprecated: ") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// fieldIdents returns identifiers defining field objects of a field
+// declaration. For embedded fields it is the identifier of type name.
+func fieldIdents(field *ast.Field) []*ast.Ident {
+	if len(field.Names) > 0 {
+		return field.Names
+	}
+
+	typ := field.Type
+
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+		case *ast.SelectorExpr:
+			return []*ast.Ident{t.Sel}
+		case *ast.IndexExpr:
+			typ = t.X
+		case *ast.IndexListExpr:
+			typ = t.X
+		case *ast.Ident:
+			return []*ast.Ident{t}
+		default:
+			return nil
+		}
+	}
+}
+
+// isDeprecated checks whether a field with a given name of a structure is
+// deprecated. Fields are only treated as deprecated in case
+// [Config.CheckDeprecated] is enabled.
+func (a *analyzer) isDeprecated(pass *analysis.Pass, strct *types.Struct, name string) bool {
+	if !a.config.CheckDeprecated {
+		return false
+	}
+
+	v := fieldByName(strct, name)
+
+	// facts are exported for fields of generic types, not of their instances
+	return v != nil && getPackageFacts(pass).deprecated[v.Origin()]
+}
+
+// removeDeprecated removes deprecated fields from a given list of fields of a
+// structure.
+func (a *analyzer) removeDeprecated(pass *analysis.Pass, strct *types.Struct, sf structure.Fields) structure.Fields {
+	if !a.config.CheckDeprecated {
+		return sf
+	}
+
+	res := make(structure.Fields, 0, len(sf))
+
+	for _, f := range sf {
+		if !a.isDeprecated(pass, strct, f.Name) {
+			res = append(res, f)
+		}
+	}
+
+	if len(res) == 0 {
+		return nil
+	}
+
+	return res
+}
+
+// checkForbiddenFields reports fields of a keyed literal, that are marked with
+// `exhaustruct:"forbidden"` tag, or are deprecated. Unkeyed literals are not
+// reported, since they have to list all fields.
+func (a *analyzer) checkForbiddenFields(
+	pass *analysis.Pass,
+	lit *ast.CompositeLit,
+	structTyp *types.Struct,
+	info *TypeInfo,
+	sev Severity,
+) []Finding {
+	sf := a.structFields.Get(structTyp)
+
+	var findings []Finding
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		f := sf.Get(key.Name)
+
+		switch {
+		case f == nil:
+			continue
+		case f.Forbidden:
+			findings = append(findings, newFinding(kv.Pos(), RuleForbiddenField, sev,
+				"%s.%s is forbidden and must not be set%s", info.ShortString(), f.Name, info.aliasNote()))
+		case a.isDeprecated(pass, structTyp, f.Name):
+			findings = append(findings, newFinding(kv.Pos(), RuleForbiddenField, sev,
+				"%s.%s is deprecated and must not be set%s", info.ShortString(), f.Name, info.aliasNote()))
+		}
+	}
+
+	return findings
+}
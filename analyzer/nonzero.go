@@ -0,0 +1,114 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+
+	"dev.gaijin.team/go/exhaustruct/v4/internal/structure"
+)
+
+// checkNonZeroFields reports fields of a literal, that are required to be
+// non-zero either by `exhaustruct:"nonzero"` tag or by [Config.NonZeroRx], but
+// are set to constant zero value.
+func (a *analyzer) checkNonZeroFields(
+	pass *analysis.Pass,
+	lit *ast.CompositeLit,
+	structTyp *types.Struct,
+	info *TypeInfo,
+	sev Severity,
+) []Finding {
+	sf := a.structFields.Get(structTyp)
+
+	var findings []Finding
+
+	for i, elt := range lit.Elts {
+		var (
+			f     *structure.Field
+			value = elt
+		)
+
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			key, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				continue
+			}
+
+			f = sf.Get(key.Name)
+			value = kv.Value
+		} else if i < len(sf) {
+			f = sf[i]
+		}
+
+		if f == nil || !a.isNonZeroField(info, f) || !isZeroValue(pass.TypesInfo, value) {
+			continue
+		}
+
+		findings = append(findings, newFinding(value.Pos(), RuleZeroValue, sev,
+			"%s.%s must not be zero%s", info.ShortString(), f.Name, info.aliasNote()))
+	}
+
+	return findings
+}
+
+// isNonZeroField checks whether a field of a given type must not be set to
+// zero value.
+func (a *analyzer) isNonZeroField(info *TypeInfo, f *structure.Field) bool {
+	if f.NonZero {
+		return true
+	}
+
+	if len(a.config.nonZeroPatterns) == 0 {
+		return false
+	}
+
+	names := a.config.typeNames(info)
+	for i := range names {
+		names[i] += "." + f.Name
+	}
+
+	return matchAny(a.config.nonZeroPatterns, names)
+}
+
+// isZeroValue checks whether an expression is a constant zero value: `nil`,
+// empty string, zero number, `false` or an empty structure or array literal.
+func isZeroValue(info *types.Info, expr ast.Expr) bool {
+	expr = ast.Unparen(expr)
+
+	// empty slices and maps are not nil, so only empty structures and arrays
+	// are zero values
+	if lit, ok := expr.(*ast.CompositeLit); ok {
+		switch info.TypeOf(lit).Underlying().(type) {
+		case *types.Struct, *types.Array:
+			return len(lit.Elts) == 0
+		default:
+			return false
+		}
+	}
+
+	tv, ok := info.Types[expr]
+	if !ok {
+		return false
+	}
+
+	if tv.IsNil() {
+		return true
+	}
+
+	if tv.Value == nil {
+		return false
+	}
+
+	switch tv.Value.Kind() {
+	case constant.Bool:
+		return !constant.BoolVal(tv.Value)
+	case constant.String:
+		return constant.StringVal(tv.Value) == ""
+	case constant.Int, constant.Float, constant.Complex:
+		return constant.Sign(tv.Value) == 0
+	default:
+		return false
+	}
+}
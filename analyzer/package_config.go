@@ -0,0 +1,142 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"dev.gaijin.team/go/exhaustruct/v4/internal/comment"
+)
+
+// conflictKeyer is implemented by flag values that can be set multiple times,
+// e.g. lists, in order to define which of settings are conflicting.
+type conflictKeyer interface {
+	// conflictKey returns a key of a setting, settings with same key and
+	// different values are conflicting. The second return value is false in
+	// case setting never conflicts with others.
+	conflictKey(name, value string) (string, bool)
+}
+
+func (stringSliceFlag) conflictKey(string, string) (string, bool) { return "", false }
+
+func (thresholdsFlag) conflictKey(string, string) (string, bool) { return "", false }
+
+func (calleeRulesFlag) conflictKey(string, string) (string, bool) { return "", false }
+
+func (siteRulesFlag) conflictKey(string, string) (string, bool) { return "", false }
+
+func (contextPoliciesFlag) conflictKey(name, value string) (string, bool) {
+	ctx, _, _ := strings.Cut(value, "=")
+	return name + " " + ctx, true
+}
+
+// packageSetting is a setting applied by `//exhaustruct:config` directive.
+type packageSetting struct {
+	arg   string
+	value string
+	pos   token.Pos
+	file  *token.File
+}
+
+// packageForbiddenSettings is a list of flags that can not be used in
+// `//exhaustruct:config` directive: profiles are applied, and analyzers
+// required to collect facts from dependencies are chosen, before package
+// configuration is known.
+//
+//nolint:gochecknoglobals
+var packageForbiddenSettings = []string{"profile", "profile-setting", "check-constructors", "check-deprecated"}
+
+// forPackage returns an analyzer to check a given package with. In case
+// package doc comments contain `//exhaustruct:config` directives, a new
+// analyzer with adjusted configuration is returned, otherwise the analyzer
+// itself.
+//
+// Directives are in form of `//exhaustruct:config setting[=value] ...`, where
+// setting is a name of any flag without leading dash, except of
+// [packageForbiddenSettings].
+// Invalid settings, as well as settings conflicting with ones of other files,
+// are reported. Within a single file later settings override earlier ones.
+func (a *analyzer) forPackage(pass *analysis.Pass, res *Result) *analyzer {
+	directives := a.getPackageConfigDirectives(pass, res)
+	if len(directives) == 0 {
+		return a
+	}
+
+	config := a.config.clone()
+	applied := make(map[string]packageSetting)
+
+	for _, d := range directives {
+		for _, arg := range comment.DirectiveArgs(d) {
+			name, value, hasValue := strings.Cut(arg, "=")
+			if !hasValue {
+				value = "true"
+			}
+
+			if slices.Contains(packageForbiddenSettings, name) {
+				res.report(pass, newFinding(d.Pos(), RuleInvalidDirective, a.config.defaultSeverity(),
+					"invalid %s setting %s: setting can only be set globally", comment.DirectiveConfig, arg))
+
+				continue
+			}
+
+			updated, key, err := applySetting(config, name, value, hasValue)
+			if err != nil {
+				res.report(pass, newFinding(d.Pos(), RuleInvalidDirective, a.config.defaultSeverity(),
+					"invalid %s setting %s: %s", comment.DirectiveConfig, arg, err))
+
+				continue
+			}
+
+			if key != "" {
+				file := pass.Fset.File(d.Pos())
+
+				prev, ok := applied[key]
+				if ok && prev.file != file && prev.value != value {
+					res.report(pass, newFinding(d.Pos(), RuleInvalidDirective, a.config.defaultSeverity(),
+						"%s setting %s conflicts with %s set at %s",
+						comment.DirectiveConfig, arg, prev.arg, pass.Fset.Position(prev.pos)))
+
+					continue
+				}
+
+				if !ok || prev.file == file {
+					applied[key] = packageSetting{arg: arg, value: value, pos: d.Pos(), file: file}
+				}
+			}
+
+			config = updated
+		}
+	}
+
+	return newAnalyzer(a.name, config)
+}
+
+// getPackageConfigDirectives returns all `//exhaustruct:config` directives
+// placed in package doc comments. Config directives placed anywhere else are
+// reported. Generated files are skipped regardless of
+// [Config.CheckGenerated], so generators can not change package
+// configuration unnoticed.
+func (a *analyzer) getPackageConfigDirectives(pass *analysis.Pass, res *Result) []*ast.Comment {
+	var directives []*ast.Comment
+
+	for _, f := range pass.Files {
+		if ast.IsGenerated(f) {
+			continue
+		}
+
+		for _, c := range comment.FindDirectives(f.Comments, comment.DirectiveConfig) {
+			if f.Doc != nil && f.Doc.Pos() <= c.Pos() && c.End() <= f.Doc.End() {
+				directives = append(directives, c)
+				continue
+			}
+
+			res.report(pass, newFinding(c.Pos(), RuleInvalidDirective, a.config.defaultSeverity(),
+				"%s directive must be placed in package doc comment", comment.DirectiveConfig))
+		}
+	}
+
+	return directives
+}
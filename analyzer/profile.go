@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+)
+
+// profileForbiddenSettings is a list of flags that can not be used as profile
+// settings.
+//
+//nolint:gochecknoglobals
+var profileForbiddenSettings = []string{"profile", "profile-setting"}
+
+// withProfile returns a copy of the config with settings of selected profile
+// applied. Returned config has no profiles, so it is safe to prepare it
+// multiple times.
+func (c *Config) withProfile() (Config, error) {
+	cfg := c.clone()
+	cfg.Profile = ""
+	cfg.Profiles = nil
+
+	if c.Profile == "" {
+		return cfg, nil
+	}
+
+	settings, ok := c.Profiles[c.Profile]
+	if !ok {
+		return cfg, e.New("unknown profile", fields.F("profile", c.Profile))
+	}
+
+	for _, s := range settings {
+		name, value, hasValue := strings.Cut(s, "=")
+
+		for _, f := range profileForbiddenSettings {
+			if name == f {
+				return cfg, e.New("setting is not allowed in profile",
+					fields.F("profile", c.Profile), fields.F("setting", name))
+			}
+		}
+
+		var err error
+
+		cfg, _, err = applySetting(cfg, name, value, hasValue)
+		if err != nil {
+			return cfg, e.NewFrom("apply profile setting", err,
+				fields.F("profile", c.Profile), fields.F("setting", s))
+		}
+	}
+
+	return cfg, nil
+}
+
+// validateProfiles checks that selected profile exists and settings of all
+// profiles are valid.
+func (c *Config) validateProfiles() error {
+	if c.Profile != "" {
+		if _, ok := c.Profiles[c.Profile]; !ok {
+			return e.New("unknown profile", fields.F("profile", c.Profile))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(c.Profiles)) {
+		cc := *c
+		cc.Profile = name
+
+		if _, err := cc.withProfile(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// profileSettingsFlag implements flag.Value interface for
+// map[string][]string profiles field.
+type profileSettingsFlag struct {
+	m *map[string][]string
+}
+
+func (f profileSettingsFlag) String() string {
+	if f.m == nil {
+		return ""
+	}
+
+	s := make([]string, 0, len(*f.m))
+	for name, settings := range *f.m {
+		for _, setting := range settings {
+			s = append(s, name+":"+setting)
+		}
+	}
+
+	sort.Strings(s)
+
+	return strings.Join(s, ",")
+}
+
+func (f profileSettingsFlag) Set(value string) error {
+	name, setting, ok := strings.Cut(value, ":")
+	if !ok || name == "" || setting == "" {
+		return e.New("profile setting must be in form of <profile>:<setting>[=<value>]",
+			fields.F("profile-setting", value))
+	}
+
+	if *f.m == nil {
+		*f.m = make(map[string][]string)
+	}
+
+	(*f.m)[name] = append((*f.m)[name], setting)
+
+	return nil
+}
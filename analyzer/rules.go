@@ -0,0 +1,81 @@
+package analyzer
+
+import (
+	"strings"
+)
+
+// docsURL is a base URL of the rules documentation.
+const docsURL = "https://github.com/GaijinEntertainment/go-exhaustruct#"
+
+// Rule describes a kind of issue reported by the analyzer.
+//
+// Code and Name of the rule are stable and can be safely used in suppressions,
+// exclusions and any other external tooling. Code is reported as a category of
+// [analysis.Diagnostic].
+type Rule struct {
+	Code string
+	Name string
+}
+
+//nolint:gochecknoglobals
+var (
+	// RuleMissingFields is reported when keyed literal misses required fields.
+	RuleMissingFields = Rule{Code: "EXS001", Name: "missing-fields"}
+
+	// RuleEmptyLiteral is reported when literal has no fields initialized at all.
+	RuleEmptyLiteral = Rule{Code: "EXS002", Name: "empty-literal"}
+
+	// RuleUnkeyedLiteral is reported when unkeyed literal misses trailing fields.
+	RuleUnkeyedLiteral = Rule{Code: "EXS003", Name: "unkeyed-literal"}
+
+	// RuleInvalidDirective is reported when comment directive is malformed or
+	// unknown.
+	RuleInvalidDirective = Rule{Code: "EXS004", Name: "invalid-directive"}
+
+	// RuleConstructorOnly is reported when literal of a type, that must be
+	// created with a constructor, is used outside of the type package.
+	RuleConstructorOnly = Rule{Code: "EXS005", Name: "constructor-only"}
+
+	// RuleZeroValue is reported when field, that must be non-zero, is set to
+	// constant zero value.
+	RuleZeroValue = Rule{Code: "EXS006", Name: "zero-value"}
+
+	// RuleForbiddenField is reported when literal sets a field, that is
+	// forbidden or deprecated.
+	RuleForbiddenField = Rule{Code: "EXS007", Name: "forbidden-field"}
+)
+
+// Rules returns a list of all rules analyzer is able to report.
+func Rules() []Rule {
+	return []Rule{
+		RuleMissingFields,
+		RuleEmptyLiteral,
+		RuleUnkeyedLiteral,
+		RuleInvalidDirective,
+		RuleConstructorOnly,
+		RuleZeroValue,
+		RuleForbiddenField,
+	}
+}
+
+// RuleByCode returns a rule with a given code. If no such rule exists, the
+// second return value is `false`.
+func RuleByCode(code string) (Rule, bool) {
+	for _, r := range Rules() {
+		if r.Code == code {
+			return r, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+// String returns rule code along with its name, e.g. "EXS001 missing-fields".
+func (r Rule) String() string {
+	return r.Code + " " + r.Name
+}
+
+// URL returns a link to the rule documentation.
+func (r Rule) URL() string {
+	return docsURL + strings.ToLower(r.Code) + "-" + r.Name
+}
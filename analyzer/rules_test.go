@@ -0,0 +1,51 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestRules(t *testing.T) {
+	t.Parallel()
+
+	seen := make(map[string]bool)
+
+	for _, r := range analyzer.Rules() {
+		assert.False(t, seen[r.Code], "rule code %s is not unique", r.Code)
+		seen[r.Code] = true
+
+		found, ok := analyzer.RuleByCode(r.Code)
+		assert.True(t, ok)
+		assert.Equal(t, r, found)
+	}
+
+	_, ok := analyzer.RuleByCode("EXS999")
+	assert.False(t, ok)
+
+	assert.Equal(t, "EXS001 missing-fields", analyzer.RuleMissingFields.String())
+	assert.Equal(t,
+		"https://github.com/GaijinEntertainment/go-exhaustruct#exs002-empty-literal",
+		analyzer.RuleEmptyLiteral.URL(),
+	)
+}
+
+func TestDiagnosticCategories(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{AllowEmptyRx: []string{".*Allowed.*", ".*Nested.*"}})
+	require.NoError(t, err)
+
+	results := analysistest.Run(t, testdataPath, a, "empty_patterns")
+	require.Len(t, results, 1)
+	require.NotEmpty(t, results[0].Diagnostics)
+
+	for _, d := range results[0].Diagnostics {
+		assert.Equal(t, analyzer.RuleEmptyLiteral.Code, d.Category)
+		assert.Equal(t, analyzer.RuleEmptyLiteral.URL(), d.URL)
+	}
+}
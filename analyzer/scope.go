@@ -0,0 +1,153 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+	"golang.org/x/tools/go/analysis"
+)
+
+// Scope defines literals of which types are checked, depending on package and
+// module the type is declared in, relative to the package literal is located
+// in.
+type Scope string
+
+const (
+	// ScopeAll checks literals of all types.
+	ScopeAll Scope = "all"
+
+	// ScopeCrossPackage checks literals of types declared in other packages.
+	ScopeCrossPackage Scope = "cross-package"
+
+	// ScopeCrossModule checks literals of types declared in other modules,
+	// including standard library.
+	ScopeCrossModule Scope = "cross-module"
+
+	// ScopeSameModule checks literals of types declared in the same module.
+	ScopeSameModule Scope = "same-module"
+)
+
+// ParseScope converts a string into [Scope]. Empty string is treated as
+// [ScopeAll].
+func ParseScope(s string) (Scope, error) {
+	switch sc := Scope(s); sc {
+	case "":
+		return ScopeAll, nil
+	case ScopeAll, ScopeCrossPackage, ScopeCrossModule, ScopeSameModule:
+		return sc, nil
+	default:
+		return "", e.New("unknown scope", fields.F("scope", s))
+	}
+}
+
+// Set implements [flag.Value] interface.
+func (s *Scope) Set(value string) error {
+	sc, err := ParseScope(value)
+	if err != nil {
+		return err
+	}
+
+	*s = sc
+
+	return nil
+}
+
+// String implements [flag.Value] interface.
+func (s *Scope) String() string {
+	if s == nil {
+		return ""
+	}
+
+	return string(*s)
+}
+
+// inScope checks whether a type is within [Config.Scope] relative to the
+// package being analyzed.
+func (a *analyzer) inScope(pass *analysis.Pass, info *TypeInfo) bool {
+	switch a.config.Scope {
+	case ScopeCrossPackage:
+		return info.PackagePath != pass.Pkg.Path()
+	case ScopeCrossModule:
+		return !a.isSameModule(pass, info)
+	case ScopeSameModule:
+		return a.isSameModule(pass, info)
+	default:
+		return true
+	}
+}
+
+// isSameModule checks whether a type belongs to the module of the package
+// being analyzed. Packages with paths outside of module path always belong to
+// other modules, while ones within it may still belong to nested modules, so
+// the nearest directories containing go.mod files are compared, if known. In
+// case module is unknown, e.g. in GOPATH mode, only the package itself is
+// considered to be the same module.
+func (a *analyzer) isSameModule(pass *analysis.Pass, info *TypeInfo) bool {
+	if info.PackagePath == pass.Pkg.Path() {
+		return true
+	}
+
+	if pass.Module == nil || pass.Module.Path == "" {
+		return false
+	}
+
+	mod := pass.Module.Path
+	if info.PackagePath != mod && !strings.HasPrefix(info.PackagePath, mod+"/") {
+		return false
+	}
+
+	if !info.pos.IsValid() || len(pass.Files) == 0 {
+		return true
+	}
+
+	root := a.moduleRoots.find(pass.Fset.Position(pass.Files[0].Pos()).Filename)
+	typRoot := a.moduleRoots.find(pass.Fset.Position(info.pos).Filename)
+
+	return root == "" || typRoot == "" || root == typRoot
+}
+
+// moduleRoots caches directories of modules files belong to by directories of
+// files. Analysis passes do not provide module directories, so they are looked
+// up on the file system. Zero value is ready to use.
+type moduleRoots struct {
+	roots sync.Map
+}
+
+// find returns the nearest directory containing go.mod file, that is a parent
+// of a given file. Empty string is returned in case there is no such
+// directory, e.g. file name is not an absolute path.
+func (m *moduleRoots) find(filename string) string {
+	if !filepath.IsAbs(filename) {
+		return ""
+	}
+
+	dir := filepath.Dir(filename)
+
+	if root, ok := m.roots.Load(dir); ok {
+		return root.(string) //nolint:forcetypeassert
+	}
+
+	root := ""
+
+	for d := dir; ; {
+		if fi, err := os.Stat(filepath.Join(d, "go.mod")); err == nil && !fi.IsDir() {
+			root = d
+			break
+		}
+
+		parent := filepath.Dir(d)
+		if parent == d {
+			break
+		}
+
+		d = parent
+	}
+
+	m.roots.Store(dir, root)
+
+	return root
+}
@@ -0,0 +1,184 @@
+package analyzer
+
+import (
+	"go/ast"
+	"path/filepath"
+	"strings"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+	"golang.org/x/tools/go/analysis"
+
+	"dev.gaijin.team/go/exhaustruct/v4/internal/pattern"
+)
+
+// literalSite is a place literal is written in.
+type literalSite struct {
+	// pkgPath is a path of the package literal is located in.
+	pkgPath string
+
+	// filePath is a slash-separated path of the file literal is located in,
+	// relative to module root, e.g. `internal/testutil/request.go`. In case
+	// module is unknown, e.g. in GOPATH mode, it is an absolute path.
+	filePath string
+}
+
+// getLiteralSite returns a site of a given literal.
+func (a *analyzer) getLiteralSite(pass *analysis.Pass, lit *ast.CompositeLit) literalSite {
+	return literalSite{
+		pkgPath:  pass.Pkg.Path(),
+		filePath: a.getSiteFilePath(pass, pass.Fset.File(lit.Pos()).Name()),
+	}
+}
+
+// getSiteFilePath returns a path of a given file relative to the root of the
+// module being analyzed, or the file name itself in case it is not known.
+func (a *analyzer) getSiteFilePath(pass *analysis.Pass, filename string) string {
+	if pass.Module == nil || pass.Module.Path == "" {
+		return filename
+	}
+
+	root := a.moduleRoots.find(filename)
+	if root == "" {
+		return filename
+	}
+
+	rel, err := filepath.Rel(root, filename)
+	if err != nil {
+		return filename
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// matches checks whether a pattern list fully matches either package path or
+// file path of the site.
+func (s literalSite) matches(l pattern.List) bool {
+	return l.MatchFullString(s.pkgPath) || l.MatchFullString(s.filePath)
+}
+
+// isSiteIncluded checks whether literals written at a given site should be
+// processed basing off site include and exclude patterns.
+func (a *analyzer) isSiteIncluded(site literalSite) bool {
+	if len(a.config.includeSitePatterns) > 0 && !site.matches(a.config.includeSitePatterns) {
+		return false
+	}
+
+	return len(a.config.excludeSitePatterns) == 0 || !site.matches(a.config.excludeSitePatterns)
+}
+
+// SiteRule defines a policy for structures of matching types, written at
+// matching sites, e.g. to allow empty requests only in tests.
+type SiteRule struct {
+	// SiteRx is a regular expression to match either package path or file path
+	// of the place literal is written in, e.g. `.*_test\.go`. File path is
+	// relative to module root, e.g. `examples/.*`.
+	SiteRx      string
+	sitePattern pattern.List `exhaustruct:"optional"`
+
+	// TypeRx is a regular expression to match full type name, same as
+	// [Config.IncludeRx].
+	TypeRx      string
+	typePattern pattern.List `exhaustruct:"optional"`
+
+	// Policy is applied to matching structures. [PolicyStrict] disables all
+	// empty and partial allowances, except of callee rules.
+	Policy Policy
+}
+
+// ParseSiteRule parses site rule from string in form of
+// `<site-rx>:<type-rx>=<policy>`, e.g. `.*_test\.go:.*/pkg\.Request=allow-empty`.
+// Site is separated by the first colon, so type regular expression might
+// contain colons, e.g. `(?:Request|Response)`, but site one might not.
+func ParseSiteRule(str string) (SiteRule, error) {
+	errInvalid := e.New("site rule must be in form of <site-rx>:<type-rx>=<policy>", fields.F("site-rule", str))
+
+	rest, policy, ok := cutLast(str, "=")
+	if !ok {
+		return SiteRule{}, errInvalid
+	}
+
+	site, typ, ok := strings.Cut(rest, ":")
+	if !ok {
+		return SiteRule{}, errInvalid
+	}
+
+	return SiteRule{
+		SiteRx: site,
+		TypeRx: typ,
+		Policy: Policy(policy),
+	}, nil
+}
+
+// prepare validates site rule and compiles its patterns.
+func (r *SiteRule) prepare() error {
+	var err error
+
+	if _, err = ParsePolicy(string(r.Policy)); err != nil {
+		return err
+	}
+
+	r.sitePattern, err = pattern.NewList(r.SiteRx)
+	if err != nil {
+		return e.NewFrom("compile site pattern", err)
+	}
+
+	r.typePattern, err = pattern.NewList(r.TypeRx)
+	if err != nil {
+		return e.NewFrom("compile type pattern", err)
+	}
+
+	return nil
+}
+
+// matches checks whether the rule applies to a type with given names at a
+// given site.
+func (r *SiteRule) matches(site literalSite, typeNames []string) bool {
+	return site.matches(r.sitePattern) && matchAny(r.typePattern, typeNames)
+}
+
+// String returns site rule in the same form it is parsed by [ParseSiteRule].
+func (r SiteRule) String() string {
+	return r.SiteRx + ":" + r.TypeRx + "=" + string(r.Policy)
+}
+
+// getSitePolicy returns a policy of the first site rule matching a type with
+// given names at a given site, if any.
+func (a *analyzer) getSitePolicy(site literalSite, typeNames []string) (Policy, bool) {
+	for i := range a.config.SiteRules {
+		if a.config.SiteRules[i].matches(site, typeNames) {
+			return a.config.SiteRules[i].Policy, true
+		}
+	}
+
+	return "", false
+}
+
+// siteRulesFlag implements flag.Value interface for []SiteRule fields.
+type siteRulesFlag struct {
+	slice *[]SiteRule
+}
+
+func (f siteRulesFlag) String() string {
+	if f.slice == nil {
+		return ""
+	}
+
+	s := make([]string, 0, len(*f.slice))
+	for _, r := range *f.slice {
+		s = append(s, r.String())
+	}
+
+	return strings.Join(s, ",")
+}
+
+func (f siteRulesFlag) Set(value string) error {
+	r, err := ParseSiteRule(value)
+	if err != nil {
+		return err
+	}
+
+	*f.slice = append(*f.slice, r)
+
+	return nil
+}
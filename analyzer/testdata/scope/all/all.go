@@ -0,0 +1,20 @@
+package all
+
+import (
+	"image"
+
+	"example.com/app/types"
+	"example.com/lib"
+)
+
+type Local struct {
+	A string
+	B string
+}
+
+func literals() {
+	_ = Local{A: ""}         // want "all.Local is missing field B"
+	_ = types.Shared{A: ""}  // want "types.Shared is missing field B"
+	_ = lib.Request{URL: ""} // want "lib.Request is missing field Method"
+	_ = image.Point{X: 0}    // want "image.Point is missing field Y"
+}
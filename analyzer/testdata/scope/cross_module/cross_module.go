@@ -0,0 +1,24 @@
+package cross_module
+
+import (
+	"image"
+
+	"example.com/app/tools"
+	"example.com/app/types"
+	"example.com/lib"
+)
+
+type Local struct {
+	A string
+	B string
+}
+
+func literals() {
+	_ = Local{A: ""}
+	_ = types.Shared{A: ""}
+	_ = lib.Request{URL: ""} // want "lib.Request is missing field Method"
+	_ = image.Point{X: 0}    // want "image.Point is missing field Y"
+
+	// nested module is a separate module, despite of path prefix
+	_ = tools.Config{Name: ""} // want "tools.Config is missing field Path"
+}
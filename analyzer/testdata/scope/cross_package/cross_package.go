@@ -0,0 +1,20 @@
+package cross_package
+
+import (
+	"image"
+
+	"example.com/app/types"
+	"example.com/lib"
+)
+
+type Local struct {
+	A string
+	B string
+}
+
+func literals() {
+	_ = Local{A: ""}
+	_ = types.Shared{A: ""}  // want "types.Shared is missing field B"
+	_ = lib.Request{URL: ""} // want "lib.Request is missing field Method"
+	_ = image.Point{X: 0}    // want "image.Point is missing field Y"
+}
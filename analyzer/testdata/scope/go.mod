@@ -0,0 +1,13 @@
+module example.com/app
+
+go 1.24
+
+require (
+	example.com/app/tools v0.0.0
+	example.com/lib v0.0.0
+)
+
+replace (
+	example.com/app/tools => ./tools
+	example.com/lib => ./lib
+)
@@ -0,0 +1,6 @@
+package lib
+
+type Request struct {
+	Method string
+	URL    string
+}
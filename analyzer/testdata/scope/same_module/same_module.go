@@ -0,0 +1,24 @@
+package same_module
+
+import (
+	"image"
+
+	"example.com/app/tools"
+	"example.com/app/types"
+	"example.com/lib"
+)
+
+type Local struct {
+	A string
+	B string
+}
+
+func literals() {
+	_ = Local{A: ""}        // want "same_module.Local is missing field B"
+	_ = types.Shared{A: ""} // want "types.Shared is missing field B"
+	_ = lib.Request{URL: ""}
+	_ = image.Point{X: 0}
+
+	// nested module is a separate module, despite of path prefix
+	_ = tools.Config{Name: ""}
+}
@@ -0,0 +1,7 @@
+package tools
+
+// Config is declared in a module nested into example.com/app.
+type Config struct {
+	Name string
+	Path string
+}
@@ -0,0 +1,6 @@
+package types
+
+type Shared struct {
+	A string
+	B string
+}
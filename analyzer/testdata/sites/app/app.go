@@ -0,0 +1,10 @@
+package app
+
+type Request struct {
+	Method string
+	URL    string
+}
+
+func literals() {
+	_ = Request{} // want "app.Request is missing fields Method, URL"
+}
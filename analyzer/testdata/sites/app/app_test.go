@@ -0,0 +1,8 @@
+package app
+
+import "testing"
+
+func TestLiterals(t *testing.T) {
+	_ = Request{}
+	_ = Request{Method: ""} // want "app.Request is missing field URL"
+}
@@ -0,0 +1,7 @@
+package main
+
+import "example.com/sites/app"
+
+func main() {
+	_ = app.Request{}
+}
@@ -0,0 +1,7 @@
+package testutil
+
+import "example.com/sites/app"
+
+func NewRequest() app.Request {
+	return app.Request{}
+}
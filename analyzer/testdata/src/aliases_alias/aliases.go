@@ -0,0 +1,21 @@
+package aliases_alias
+
+type Local struct {
+	A string
+	B int
+}
+
+type Hidden struct {
+	A string
+	B int
+}
+
+type Opts = Local
+type Visible = Hidden
+
+func literals() {
+	_ = Local{A: ""} // want "aliases_alias.Local is missing field B"
+	_ = Hidden{}
+	_ = Opts{A: ""}
+	_ = Visible{} // want "aliases_alias.Hidden is missing fields A, B \\(via alias aliases_alias.Visible\\)"
+}
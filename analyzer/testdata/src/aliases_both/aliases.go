@@ -0,0 +1,21 @@
+package aliases_both
+
+type Local struct {
+	A string
+	B int
+}
+
+type Hidden struct {
+	A string
+	B int
+}
+
+type Opts = Local
+type Visible = Hidden
+
+func literals() {
+	_ = Local{A: ""} // want "aliases_both.Local is missing field B"
+	_ = Hidden{}
+	_ = Opts{A: ""}
+	_ = Visible{}
+}
@@ -0,0 +1,21 @@
+package aliases_target
+
+type Local struct {
+	A string
+	B int
+}
+
+type Hidden struct {
+	A string
+	B int
+}
+
+type Opts = Local
+type Visible = Hidden
+
+func literals() {
+	_ = Local{A: ""} // want "aliases_target.Local is missing field B"
+	_ = Hidden{}
+	_ = Opts{A: ""} // want "aliases_target.Local is missing field B \\(via alias aliases_target.Opts\\)"
+	_ = Visible{}
+}
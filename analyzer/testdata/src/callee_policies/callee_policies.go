@@ -0,0 +1,61 @@
+package callee_policies
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+type Payload struct {
+	A string
+	B int
+}
+
+type MyErr struct {
+	Code int
+	Msg  string
+}
+
+func (MyErr) Error() string { return "error" }
+
+type Options struct {
+	Addr    string
+	Timeout int
+}
+
+type Client struct{}
+
+func (*Client) Do(_ string, _ Payload) {}
+
+func NewServer(_ Options, _ Payload) {}
+
+func consume(_ any) {}
+
+func shouldPassDecodeTarget(data []byte) {
+	_ = json.Unmarshal(data, &Payload{})
+}
+
+func shouldFailPartialDecodeTarget(data []byte) {
+	_ = json.Unmarshal(data, &Payload{A: ""}) // want "callee_policies.Payload is missing field B"
+}
+
+func shouldPassErrorsAs(err error) {
+	_ = errors.As(err, &MyErr{})
+	_ = errors.As(err, &MyErr{Code: 1})
+}
+
+func shouldPassMethodArgument(c *Client) {
+	c.Do("", Payload{})
+}
+
+func shouldFailEnforcedConstructorArgument() {
+	NewServer(Options{}, Payload{A: "", B: 0}) // want "callee_policies.Options is missing fields Addr, Timeout"
+}
+
+func shouldFailArgumentWithoutRule() {
+	NewServer(Options{Addr: "", Timeout: 0}, Payload{}) // want "callee_policies.Payload is missing fields A, B"
+}
+
+func shouldFailOtherCallees() {
+	consume(Options{})
+	consume(Payload{}) // want "callee_policies.Payload is missing fields A, B"
+}
@@ -0,0 +1,91 @@
+package context_policies
+
+type TestStruct struct {
+	A string
+	B int
+}
+
+type Outer struct {
+	Inner TestStruct
+	Value string
+}
+
+type Iface interface {
+	Method()
+}
+
+func (TestStruct) Method() {}
+
+func consume(_ any) {}
+
+var _ Iface = TestStruct{}
+
+var _ Iface = &TestStruct{}
+
+var _ = TestStruct{} // want "context_policies.TestStruct is missing fields A, B"
+
+func shouldPassCallArgument() {
+	consume(TestStruct{})
+	consume(&TestStruct{})
+}
+
+func shouldFailPartialCallArgument() {
+	consume(TestStruct{A: ""}) // want "context_policies.TestStruct is missing field B"
+}
+
+func shouldPassAssignment() {
+	var v TestStruct
+
+	v = TestStruct{}
+	v = TestStruct{A: ""}
+
+	_ = v
+}
+
+func shouldPassMapValue() {
+	_ = map[string]TestStruct{"a": {}}
+	_ = map[string]*TestStruct{"a": {}}
+}
+
+func shouldFailPartialMapValue() {
+	_ = map[string]TestStruct{"a": {A: ""}} // want "context_policies.TestStruct is missing field B"
+}
+
+func shouldFailSliceElement() {
+	_ = []TestStruct{{}}    // want "context_policies.TestStruct is missing fields A, B"
+	_ = [1]TestStruct{{}}   // want "context_policies.TestStruct is missing fields A, B"
+	_ = []TestStruct{0: {}} // want "context_policies.TestStruct is missing fields A, B"
+}
+
+func shouldPassChannelSend() {
+	ch := make(chan TestStruct, 2)
+
+	ch <- TestStruct{}
+	ch <- TestStruct{B: 1}
+}
+
+func shouldPassFieldValue() {
+	_ = Outer{Inner: TestStruct{}, Value: ""}
+	_ = Outer{TestStruct{}, ""}
+}
+
+func shouldPassComparison(v TestStruct) bool {
+	return v == (TestStruct{}) || (TestStruct{}) != v
+}
+
+func shouldFailDeclaration() {
+	v := TestStruct{} // want "context_policies.TestStruct is missing fields A, B"
+	_ = v
+}
+
+func shouldPassReturn() (TestStruct, bool) {
+	if true {
+		return TestStruct{}, true
+	}
+
+	return TestStruct{A: ""}, true
+}
+
+func shouldFailOtherContexts() {
+	_ = (TestStruct{}).A // want "context_policies.TestStruct is missing fields A, B"
+}
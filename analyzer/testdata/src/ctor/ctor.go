@@ -0,0 +1,56 @@
+package ctor
+
+// Client must be created with NewClient.
+//
+//exhaustruct:constructor NewClient
+type Client struct {
+	Addr string
+}
+
+func NewClient(addr string) *Client {
+	return &Client{Addr: addr}
+}
+
+// Server has unexported fields and exported NewServer.
+type Server struct {
+	Addr    string
+	handler func()
+}
+
+func NewServer(addr string) Server {
+	return Server{Addr: addr, handler: nil}
+}
+
+// Plain has unexported fields, but no constructor.
+type Plain struct {
+	A string
+	b int
+}
+
+// Exported has no unexported fields.
+type Exported struct {
+	A string
+}
+
+func NewExported() Exported {
+	return Exported{A: ""}
+}
+
+//exhaustruct:constructor NewMissing // want "//exhaustruct:constructor directive names unknown function NewMissing"
+type Broken struct {
+	A string
+}
+
+//exhaustruct:constructor // want "//exhaustruct:constructor directive requires a constructor function name"
+type Broken2 struct {
+	A string
+}
+
+//exhaustruct:constructor MakeWrong // want "//exhaustruct:constructor directive names function MakeWrong, that does not return Wrong or \\*Wrong"
+type Wrong struct {
+	A string
+}
+
+func MakeWrong() int {
+	return 0
+}
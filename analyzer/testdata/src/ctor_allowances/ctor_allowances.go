@@ -0,0 +1,30 @@
+package ctor_allowances
+
+import (
+	"errors"
+
+	"ctor"
+)
+
+type Local struct {
+	A string
+	B int
+}
+
+func empty() {
+	_ = Local{}
+	_ = ctor.Client{}  // want "ctor.Client must be created with constructor ctor.NewClient"
+	_ = &ctor.Client{} // want "ctor.Client must be created with constructor ctor.NewClient"
+}
+
+func shouldFailErrorReturn() (ctor.Client, error) {
+	return ctor.Client{}, errors.New("error") // want "ctor.Client must be created with constructor ctor.NewClient"
+}
+
+func shouldPassErrorReturn() (Local, error) {
+	return Local{}, errors.New("error")
+}
+
+func shouldFailPartialErrorReturn() (*ctor.Client, error) {
+	return &ctor.Client{Addr: ""}, errors.New("error") // want "ctor.Client must be created with constructor ctor.NewClient"
+}
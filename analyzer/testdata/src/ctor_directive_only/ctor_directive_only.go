@@ -0,0 +1,8 @@
+package ctor_directive_only
+
+import "ctor"
+
+func literals() {
+	_ = ctor.Client{Addr: ""} // want "ctor.Client must be created with constructor ctor.NewClient"
+	_ = ctor.Server{Addr: ""}
+}
@@ -0,0 +1,8 @@
+package ctor_unchecked
+
+import "ctor"
+
+func literals() {
+	_ = ctor.Client{Addr: ""}
+	_ = ctor.Server{Addr: ""}
+}
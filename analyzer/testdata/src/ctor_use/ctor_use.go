@@ -0,0 +1,16 @@
+package ctor_use
+
+import "ctor"
+
+type ClientAlias = ctor.Client
+
+func literals() {
+	_ = ctor.Client{Addr: ""}  // want "ctor.Client must be created with constructor ctor.NewClient"
+	_ = &ctor.Client{Addr: ""} // want "ctor.Client must be created with constructor ctor.NewClient"
+	_ = ClientAlias{Addr: ""}  // want "ctor.Client must be created with constructor ctor.NewClient \\(via alias ctor_use.ClientAlias\\)"
+	_ = ctor.Server{Addr: ""}  // want "ctor.Server must be created with constructor ctor.NewServer"
+	_ = ctor.Plain{A: ""}
+	_ = ctor.Exported{A: ""}
+
+	_ = ctor.Client{Addr: ""} //exhaustruct:ignore
+}
@@ -0,0 +1,118 @@
+package deep
+
+type Server struct {
+	Addr string
+	TLS  TLSConfig
+	Log  *LogConfig
+	Meta any
+	Opts struct {
+		A string
+		B string
+	}
+}
+
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	Client   ClientAuth
+}
+
+type ClientAuth struct {
+	CA       string
+	Required bool `exhaustruct:"optional"`
+}
+
+type LogConfig struct {
+	Level string
+}
+
+func shouldPassComplete() {
+	_ = Server{
+		Addr: "",
+		TLS: TLSConfig{
+			CertFile: "",
+			KeyFile:  "",
+			Client:   ClientAuth{CA: ""},
+		},
+		Log:  nil,
+		Meta: nil,
+		Opts: struct {
+			A string
+			B string
+		}{A: "", B: ""},
+	}
+}
+
+func shouldFailNested() {
+	_ = Server{
+		Addr: "",
+		TLS: TLSConfig{ // want "deep.Server.TLS.KeyFile, deep.Server.TLS.Client are missing"
+			CertFile: "",
+		},
+		Log:  nil,
+		Meta: nil,
+		Opts: struct { // want "deep.Server.Opts.B is missing"
+			A string
+			B string
+		}{A: ""},
+	}
+}
+
+func shouldFailTransitively() {
+	_ = Server{
+		Addr: "",
+		TLS: TLSConfig{
+			CertFile: "",
+			KeyFile:  "",
+			Client:   ClientAuth{}, // want "deep.Server.TLS.Client.CA is missing"
+		},
+		Log:  nil,
+		Meta: nil,
+		Opts: struct {
+			A string
+			B string
+		}{A: "", B: ""},
+	}
+}
+
+func shouldFailUnkeyed() {
+	_ = Server{"", (TLSConfig{"", "", ClientAuth{}}), nil, nil, struct { // want "deep.Server.TLS.Client.CA is missing"
+		A string
+		B string
+	}{"", ""}}
+}
+
+func shouldFailOmittedAsWhole() {
+	_ = Server{Addr: "", Log: nil, Meta: nil} // want "deep.Server is missing fields TLS, Opts"
+}
+
+func shouldPassPointerAndInterfaceFields() {
+	_ = Server{
+		Addr: "",
+		TLS:  TLSConfig{CertFile: "", KeyFile: "", Client: ClientAuth{CA: ""}},
+		Log:  &LogConfig{},
+		Meta: LogConfig{},
+		Opts: struct {
+			A string
+			B string
+		}{A: "", B: ""},
+	}
+}
+
+func shouldPassIgnoredNested() {
+	_ = Server{
+		Addr: "",
+		//exhaustruct:ignore
+		TLS:  TLSConfig{CertFile: ""},
+		Log:  nil,
+		Meta: nil,
+		Opts: struct {
+			A string
+			B string
+		}{A: "", B: ""},
+	}
+}
+
+func shouldPassNotCheckedParent() {
+	_ = TLSConfig{Client: ClientAuth{}}
+}
@@ -0,0 +1,41 @@
+package embedded_optional
+
+import "e"
+
+type Base struct {
+	ID int
+}
+
+type Meta struct {
+	Labels []string `exhaustruct:"optional"`
+}
+
+type Named interface {
+	Name() string
+}
+
+type Service struct {
+	Base
+	*Meta
+	Named
+	e.External
+
+	Addr string
+}
+
+func literals() {
+	_ = Service{
+		Addr: "",
+	}
+	_ = Service{ // want "embedded_optional.Service is missing field Addr"
+		Base: Base{ID: 0},
+		Meta: nil,
+	}
+	_ = Service{
+		Base:     Base{ID: 0},
+		Meta:     nil,
+		Named:    nil,
+		External: e.External{A: "", B: ""},
+		Addr:     "",
+	}
+}
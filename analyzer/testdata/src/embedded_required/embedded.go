@@ -0,0 +1,41 @@
+package embedded_required
+
+import "e"
+
+type Base struct {
+	ID int
+}
+
+type Meta struct {
+	Labels []string `exhaustruct:"optional"`
+}
+
+type Named interface {
+	Name() string
+}
+
+type Service struct {
+	Base
+	*Meta
+	Named
+	e.External
+
+	Addr string
+}
+
+func literals() {
+	_ = Service{ // want "embedded_required.Service is missing embedded fields Base \\(embedded_required.Base\\), Meta \\(\\*embedded_required.Meta\\), Named \\(embedded_required.Named\\), External \\(e.External\\)"
+		Addr: "",
+	}
+	_ = Service{ // want "embedded_required.Service is missing field Addr, embedded fields Named \\(embedded_required.Named\\), External \\(e.External\\)"
+		Base: Base{ID: 0},
+		Meta: nil,
+	}
+	_ = Service{
+		Base:     Base{ID: 0},
+		Meta:     nil,
+		Named:    nil,
+		External: e.External{A: "", B: ""},
+		Addr:     "",
+	}
+}
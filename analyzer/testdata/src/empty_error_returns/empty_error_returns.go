@@ -57,4 +57,112 @@ func shouldFailAnonymousFunctionReturningEmptyError() (TestStruct, error) {
 func shouldFailEmptyNestedStructWithNonNilErr() ([]TestStruct, error) {
 	// Should fail: TestStruct{} in slice should be checked even with non-nil error
 	return []TestStruct{{}}, os.ErrNotExist // want "empty_error_returns.TestStruct is missing field A"
-}
\ No newline at end of file
+}
+
+type PairStruct struct {
+	A string
+	B string
+}
+
+func consume(_ any) {}
+
+func shouldPassEmptyStructThroughVariable(fail bool) (TestStruct, error) {
+	// Should pass: variable is only returned along with non-nil errors
+	out := TestStruct{}
+	if fail {
+		return out, os.ErrNotExist
+	}
+
+	return out, fmt.Errorf("error message")
+}
+
+func shouldPassPointerThroughVariable() (*TestStruct, error) {
+	res := &TestStruct{}
+
+	return res, os.ErrNotExist
+}
+
+func shouldPassVariableWithFieldAccess() (TestStruct, error) {
+	var out = TestStruct{}
+	out.A = out.A + "suffix"
+
+	return out, os.ErrNotExist
+}
+
+func shouldPassAssignedVariable() (*TestStruct, error) {
+	var out TestStruct
+	out = TestStruct{}
+
+	return &out, os.ErrNotExist
+}
+
+func shouldPassPartialThroughVariableInCommaOk() (PairStruct, bool) {
+	out := PairStruct{A: ""}
+
+	return out, false
+}
+
+func shouldFailVariableReturnedOnSuccess(fail bool) (TestStruct, error) {
+	out := TestStruct{} // want "empty_error_returns.TestStruct is missing field A"
+	if fail {
+		return out, os.ErrNotExist
+	}
+
+	return out, nil
+}
+
+func shouldFailEscapingVariable() (TestStruct, error) {
+	out := TestStruct{} // want "empty_error_returns.TestStruct is missing field A"
+	consume(out)
+
+	return out, os.ErrNotExist
+}
+
+func shouldFailNotReturnedVariable() {
+	out := TestStruct{} // want "empty_error_returns.TestStruct is missing field A"
+	_ = out
+}
+
+func shouldFailCapturedVariable() (TestStruct, error) {
+	out := TestStruct{} // want "empty_error_returns.TestStruct is missing field A"
+	fn := func() TestStruct { return out }
+	_ = fn
+
+	return out, os.ErrNotExist
+}
+
+func shouldFailPartialThroughVariableInErrorReturn() (PairStruct, error) {
+	out := PairStruct{A: ""} // want "empty_error_returns.PairStruct is missing field B"
+
+	return out, os.ErrNotExist
+}
+
+var registry []*TestStruct
+
+func (s *TestStruct) Register() { registry = append(registry, s) }
+
+func (s TestStruct) Validate() error { return nil }
+
+func shouldFailVariableWithPointerMethodCall() (TestStruct, error) {
+	out := TestStruct{} // want "empty_error_returns.TestStruct is missing field A"
+	out.Register()
+
+	return out, os.ErrNotExist
+}
+
+func shouldFailVariableWithMethodCall() (TestStruct, error) {
+	out := TestStruct{} // want "empty_error_returns.TestStruct is missing field A"
+	if err := out.Validate(); err != nil {
+		return out, err
+	}
+
+	return out, os.ErrNotExist
+}
+
+func shouldFailVariableWithMethodValue() (TestStruct, error) {
+	out := TestStruct{} // want "empty_error_returns.TestStruct is missing field A"
+	register := out.Register
+	register()
+
+	return out, os.ErrNotExist
+}
@@ -0,0 +1,17 @@
+package facts
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Handler makes the package depend on a large part of the standard library, so
+// the cost of analyzing dependencies is noticeable.
+type Handler struct {
+	Client  *http.Client
+	Decoder *json.Decoder
+}
+
+func literals() {
+	_ = Handler{Client: http.DefaultClient, Decoder: nil}
+}
@@ -0,0 +1,64 @@
+package failure_returns
+
+type TestStruct struct {
+	A string
+	B int
+}
+
+type Status struct {
+	Code int
+}
+
+type found bool
+
+func shouldPassEmptyInCommaOkFailure() (TestStruct, bool) {
+	return TestStruct{}, false
+}
+
+func shouldPassPartialInCommaOkFailure() (*TestStruct, bool) {
+	return &TestStruct{A: ""}, false
+}
+
+func shouldPassNamedBoolFailure() (TestStruct, found) {
+	return TestStruct{}, false
+}
+
+func shouldPassConstantFalseFailure() (TestStruct, bool) {
+	const ok = false
+
+	return TestStruct{}, ok
+}
+
+func shouldPassInFunctionLiteral() {
+	_ = func() (TestStruct, bool) {
+		return TestStruct{}, false
+	}
+}
+
+func shouldFailCommaOkSuccess() (TestStruct, bool) {
+	return TestStruct{}, true // want "failure_returns.TestStruct is missing fields A, B"
+}
+
+func shouldFailNonConstantBool(ok bool) (TestStruct, bool) {
+	return TestStruct{}, ok // want "failure_returns.TestStruct is missing fields A, B"
+}
+
+func shouldFailBoolNotLast() (bool, TestStruct) {
+	return false, TestStruct{} // want "failure_returns.TestStruct is missing fields A, B"
+}
+
+func shouldFailNestedInCommaOkFailure() ([]TestStruct, bool) {
+	return []TestStruct{{}}, false // want "failure_returns.TestStruct is missing fields A, B"
+}
+
+func shouldPassFailureSentinel() (TestStruct, *Status) {
+	return TestStruct{A: ""}, &Status{Code: 1}
+}
+
+func shouldFailNilFailureSentinel() (TestStruct, *Status) {
+	return TestStruct{A: ""}, nil // want "failure_returns.TestStruct is missing field B"
+}
+
+func shouldFailEmptyFailureSentinel() (TestStruct, Status) {
+	return TestStruct{A: "", B: 0}, Status{} // want "failure_returns.Status is missing field Code"
+}
@@ -0,0 +1,57 @@
+package forbidden
+
+import "time"
+
+type Options struct {
+	Addr string
+
+	// Timeout of requests.
+	//
+	// Deprecated: use Deadline instead.
+	Timeout time.Duration
+
+	Retries int // Deprecated: retries are not supported anymore.
+
+	Deadline time.Time
+
+	// CreatedAt is managed by the framework.
+	CreatedAt time.Time `exhaustruct:"forbidden"`
+}
+
+type Generic[T any] struct {
+	Value T
+
+	// Deprecated: use Value instead.
+	Legacy T
+}
+
+func shouldPassOmitted() {
+	_ = Options{Addr: "", Deadline: time.Time{}}
+}
+
+func shouldFailDeprecatedInSamePackage() {
+	_ = Options{
+		Addr:     "",
+		Timeout:  0, // want "forbidden.Options.Timeout is deprecated and must not be set"
+		Retries:  0, // want "forbidden.Options.Retries is deprecated and must not be set"
+		Deadline: time.Time{},
+	}
+}
+
+func shouldFailForbidden() {
+	_ = Options{
+		Addr:      "",
+		Deadline:  time.Time{},
+		CreatedAt: time.Now(), // want "forbidden.Options.CreatedAt is forbidden and must not be set"
+	}
+}
+
+func shouldFailMissing() {
+	_ = Options{Timeout: 0} // want "forbidden.Options is missing fields Addr, Deadline" "forbidden.Options.Timeout is deprecated and must not be set"
+}
+
+func shouldFailDeprecatedGeneric() {
+	_ = Generic[int]{Value: 0}
+	_ = Generic[int]{Value: 0, Legacy: 0} // want "forbidden.Generic.Legacy is deprecated and must not be set"
+	_ = Generic[int]{}                    // want "forbidden.Generic is missing field Value"
+}
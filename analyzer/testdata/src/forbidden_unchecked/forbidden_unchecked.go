@@ -0,0 +1,25 @@
+package forbidden_unchecked
+
+import (
+	"time"
+
+	"forbidden"
+)
+
+func shouldPassDeprecated() {
+	_ = forbidden.Options{Addr: "", Timeout: time.Second, Retries: 3, Deadline: time.Time{}}
+}
+
+func shouldFailMissingDeprecated() {
+	_ = forbidden.Options{Addr: "", Deadline: time.Time{}} // want "forbidden.Options is missing fields Timeout, Retries"
+}
+
+func shouldFailForbidden() {
+	_ = forbidden.Options{
+		Addr:      "",
+		Timeout:   time.Second,
+		Retries:   3,
+		Deadline:  time.Time{},
+		CreatedAt: time.Now(), // want "forbidden.Options.CreatedAt is forbidden and must not be set"
+	}
+}
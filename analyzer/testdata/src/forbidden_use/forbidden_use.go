@@ -0,0 +1,33 @@
+package forbidden_use
+
+import (
+	"time"
+
+	"forbidden"
+)
+
+func shouldPassOmitted() {
+	_ = forbidden.Options{Addr: "", Deadline: time.Time{}}
+}
+
+func shouldFailDeprecated() {
+	_ = forbidden.Options{
+		Addr:     "",
+		Timeout:  time.Second, // want "forbidden.Options.Timeout is deprecated and must not be set"
+		Retries:  3,           // want "forbidden.Options.Retries is deprecated and must not be set"
+		Deadline: time.Time{},
+	}
+}
+
+func shouldFailForbidden() {
+	_ = forbidden.Options{
+		Addr:      "",
+		Deadline:  time.Time{},
+		CreatedAt: time.Now(), // want "forbidden.Options.CreatedAt is forbidden and must not be set"
+	}
+}
+
+func shouldFailDeprecatedGeneric() {
+	_ = forbidden.Generic[string]{Value: ""}
+	_ = forbidden.Generic[string]{Value: "", Legacy: ""} // want "forbidden.Generic.Legacy is deprecated and must not be set"
+}
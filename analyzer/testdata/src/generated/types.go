@@ -0,0 +1,15 @@
+package generated
+
+type OwnType struct {
+	A string
+	B int
+}
+
+type ForeignType struct {
+	A string
+	B int
+}
+
+func shouldFailInRegularFile() {
+	_ = ForeignType{} // want "generated.ForeignType is missing fields A, B"
+}
@@ -82,3 +82,34 @@ func shouldHandleDirectivesOnEmbedded() {
 		Embedded: Embedded{},
 	}
 }
+
+func shouldFailOnUnknownDirectives() {
+	//exhaustruct:unknown // want "unknown directive //exhaustruct:unknown"
+	_ = TestExcluded{B: 0}
+
+	_ = TestExcluded{} //exhaustruct:ignorance // want "unknown directive //exhaustruct:ignorance"
+
+	// lookalike of a known directive does not silence the literal
+	_ = Test{A: "", B: 0, C: 0} //exhaustruct:ignorance // want "unknown directive //exhaustruct:ignorance" "i.Test is missing field D"
+}
+
+func shouldHandleSkipDirective() {
+	//exhaustruct:skip C,D some reason
+	_ = Test{A: "", B: 0}
+
+	_ = Test{A: ""} //exhaustruct:skip C,D // want "i.Test is missing field B"
+
+	//exhaustruct:skip C,Unknown // want "//exhaustruct:skip directive names unknown field Unknown of i.Test"
+	_ = Test{A: "", B: 0, D: false}
+
+	//exhaustruct:skip // want "//exhaustruct:skip directive requires a comma-separated list of fields"
+	_ = Test{A: "", B: 0, C: 0, D: false}
+
+	// directive of enclosing literal does not apply to nested ones
+	//exhaustruct:skip Embedded
+	_ = Test2{
+		External: e.External{ // want "e.External is missing field B"
+			A: "",
+		},
+	}
+}
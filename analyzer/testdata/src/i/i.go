@@ -96,7 +96,7 @@ func shouldFailEmbedded() {
 }
 
 func shouldFailEmbeddedCompletelyMissing() {
-	_ = Test2{ // want "i.Test2 is missing field Embedded"
+	_ = Test2{ // want "i.Test2 is missing embedded field Embedded \\(i.Embedded\\)"
 		External: e.External{ // want "e.External is missing field B"
 			A: "",
 		},
@@ -190,7 +190,7 @@ func shouldPassAnonymousStruct() {
 }
 
 func shouldFailAnonymousStructUnfilled() {
-	_ = struct { // want "i.<anonymous> is missing field A"
+	_ = struct { // want "i.shouldFailAnonymousStructUnfilled.<anonymous> is missing field A"
 		A string
 		B int
 	}{
@@ -230,10 +230,10 @@ type TestSynonymAlias TestSynonym
 type TestSynonymExcluded = Test
 
 func shouldFailTypeSynonyms() {
-	_ = TestSynonym{}         // want "i.Test is missing fields A, B, C, D"
-	_ = TestSynonymSynonym{}  // want "i.Test is missing fields A, B, C, D"
+	_ = TestSynonym{}         // want "i.Test is missing fields A, B, C, D \\(via alias i.TestSynonym\\)"
+	_ = TestSynonymSynonym{}  // want "i.Test is missing fields A, B, C, D \\(via alias i.TestSynonymSynonym\\)"
 	_ = TestSynonymAlias{}    // want "i.TestSynonymAlias is missing fields A, B, C, D"
-	_ = TestSynonymExcluded{} // want "i.Test is missing fields A, B, C, D"
+	_ = TestSynonymExcluded{} // want "i.Test is missing fields A, B, C, D \\(via alias i.TestSynonymExcluded\\)"
 }
 
 type TestExternalSynonym = e.External
@@ -241,8 +241,8 @@ type TestExternalSynonymSynonym = TestExternalSynonym
 type TestExternalExcludedSynonym = e.ExternalExcluded
 
 func shouldFailExternalTypeSynonyms() {
-	_ = TestExternalSynonym{}        // want "e.External is missing fields A, B"
-	_ = TestExternalSynonymSynonym{} // want "e.External is missing fields A, B"
+	_ = TestExternalSynonym{}        // want "e.External is missing fields A, B \\(via alias i.TestExternalSynonym\\)"
+	_ = TestExternalSynonymSynonym{} // want "e.External is missing fields A, B \\(via alias i.TestExternalSynonymSynonym\\)"
 }
 
 func shouldSucceedExcludedSynonyms() {
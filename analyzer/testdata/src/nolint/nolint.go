@@ -0,0 +1,29 @@
+package nolint
+
+type TestStruct struct {
+	A string
+	B int
+}
+
+func shouldPassOnNolintComments() {
+	//nolint:exhaustruct // some reason
+	_ = TestStruct{}
+
+	_ = TestStruct{} //nolint:all
+
+	_ = TestStruct{} //nolint
+
+	_ = []TestStruct{
+		{}, //nolint:govet,exhaustruct
+	}
+
+	//lint:ignore exhaustruct some reason
+	_ = TestStruct{}
+}
+
+func shouldFailOnOtherLinters() {
+	_ = TestStruct{} //nolint:govet // want "nolint.TestStruct is missing fields A, B"
+
+	//lint:ignore SA1019 some reason
+	_ = TestStruct{} // want "nolint.TestStruct is missing fields A, B"
+}
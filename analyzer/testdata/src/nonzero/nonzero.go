@@ -0,0 +1,78 @@
+package nonzero
+
+type Handler func()
+
+type Route struct {
+	ID      string  `exhaustruct:"nonzero"`
+	Handler Handler `exhaustruct:"nonzero"`
+	Port    int     `exhaustruct:"nonzero"`
+	Enabled bool    `exhaustruct:"nonzero"`
+	Tags    []string
+	Meta    Meta `exhaustruct:"optional,nonzero"`
+	Name    string
+}
+
+type Meta struct {
+	Owner string
+}
+
+const noID = ""
+
+func shouldPassNonZero(id string, h Handler) {
+	_ = Route{
+		ID:      id,
+		Handler: h,
+		Port:    80,
+		Enabled: true,
+		Tags:    nil,
+		Meta:    Meta{Owner: ""},
+		Name:    "route",
+	}
+}
+
+func shouldFailZero() {
+	_ = Route{
+		ID:      "",    // want "nonzero.Route.ID must not be zero"
+		Handler: nil,   // want "nonzero.Route.Handler must not be zero"
+		Port:    0,     // want "nonzero.Route.Port must not be zero"
+		Enabled: false, // want "nonzero.Route.Enabled must not be zero"
+		Tags:    nil,
+		Meta:    Meta{}, // want "nonzero.Route.Meta must not be zero" "nonzero.Meta is missing field Owner"
+		Name:    "",     // want "nonzero.Route.Name must not be zero"
+	}
+}
+
+func shouldFailZeroConstants() {
+	_ = Route{noID, (nil), 0x0, 1 == 2, []string{}, Meta{Owner: ""}, "route"} // want "nonzero.Route.ID must not be zero" "nonzero.Route.Handler must not be zero" "nonzero.Route.Port must not be zero" "nonzero.Route.Enabled must not be zero"
+}
+
+func shouldReportZeroAlongWithMissing() {
+	_ = Route{ // want "nonzero.Route is missing fields Handler, Port, Enabled, Tags, Name"
+		ID: "", // want "nonzero.Route.ID must not be zero"
+	}
+}
+
+type Registry struct {
+	Routes []Route         `exhaustruct:"nonzero"`
+	Index  map[string]int  `exhaustruct:"nonzero"`
+	Pair   [2]int          `exhaustruct:"nonzero"`
+	Owners map[string]Meta `exhaustruct:"nonzero"`
+}
+
+func shouldPassEmptySlicesAndMaps() {
+	_ = Registry{
+		Routes: []Route{},
+		Index:  map[string]int{},
+		Pair:   [2]int{1, 2},
+		Owners: (map[string]Meta{}),
+	}
+}
+
+func shouldFailNilSlicesAndMaps() {
+	_ = Registry{
+		Routes: nil,      // want "nonzero.Registry.Routes must not be zero"
+		Index:  nil,      // want "nonzero.Registry.Index must not be zero"
+		Pair:   [2]int{}, // want "nonzero.Registry.Pair must not be zero"
+		Owners: nil,      // want "nonzero.Registry.Owners must not be zero"
+	}
+}
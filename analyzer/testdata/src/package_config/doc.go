@@ -0,0 +1,12 @@
+// Package package_config contains structures checked with package-level
+// configuration.
+//
+//exhaustruct:config allow-empty-returns allow-empty-rx=.*Options
+//exhaustruct:config context-policy=map-value=strict context-policy=map-value=allow-partial
+//exhaustruct:config unknown-setting // want "invalid //exhaustruct:config setting unknown-setting: unknown setting"
+//exhaustruct:config allow-empty-rx // want "invalid //exhaustruct:config setting allow-empty-rx: setting requires a value"
+//exhaustruct:config threshold=.*Test=bogus // want "invalid //exhaustruct:config setting threshold=.*Test=bogus: .*"
+//exhaustruct:config profile=tests // want "invalid //exhaustruct:config setting profile=tests: setting can only be set globally"
+//exhaustruct:config profile-setting=tests:allow-empty // want "invalid //exhaustruct:config setting profile-setting=tests:allow-empty: setting can only be set globally"
+//exhaustruct:config check-constructors // want "invalid //exhaustruct:config setting check-constructors: setting can only be set globally"
+package package_config
@@ -0,0 +1,40 @@
+//exhaustruct:config allow-empty-returns=false // want `//exhaustruct:config setting allow-empty-returns=false conflicts with allow-empty-returns set at .*doc\.go:4:1`
+//exhaustruct:config allow-empty-returns=true context-policy=map-value=allow-partial
+//exhaustruct:config context-policy=map-value=strict // want `//exhaustruct:config setting context-policy=map-value=strict conflicts with context-policy=map-value=allow-partial set at .*doc\.go:5:1`
+//exhaustruct:config allow-empty-rx=.*Config
+package package_config
+
+type Test struct {
+	A string
+	B int
+}
+
+type Options struct {
+	A string
+}
+
+type Config struct {
+	A string
+}
+
+func shouldPassEmptyReturn() Test {
+	return Test{}
+}
+
+func shouldPassEmptyOptions() {
+	_ = Options{}
+	_ = Config{}
+}
+
+func shouldPassPartialMapValue() {
+	_ = map[string]Test{
+		"a": {A: ""},
+	}
+}
+
+func shouldFailDeclaration() {
+	_ = Test{} // want "package_config.Test is missing fields A, B"
+
+	//exhaustruct:config allow-empty // want "//exhaustruct:config directive must be placed in package doc comment"
+	_ = Test{A: ""} // want "package_config.Test is missing field B"
+}
@@ -0,0 +1,18 @@
+package profiles
+
+type Test struct {
+	A string
+	B int
+}
+
+func shouldPassEmpty() {
+	_ = Test{}
+}
+
+func shouldFailPartial() {
+	_ = Test{A: ""} // want "profiles.Test is missing field B"
+}
+
+func shouldPassNolintOfInstance() {
+	_ = Test{A: ""} //nolint:exhaustruct_tests
+}
@@ -0,0 +1,27 @@
+package severity
+
+type PaymentRequest struct {
+	Amount   int
+	Currency string
+}
+
+type Notification struct {
+	Text string
+}
+
+type Metadata struct {
+	Key   string
+	Value string
+}
+
+func shouldReportAsError() {
+	_ = PaymentRequest{Amount: 1} // want "severity.PaymentRequest is missing field Currency"
+}
+
+func shouldReportAsWarning() {
+	_ = Notification{} // want "severity.Notification is missing field Text"
+}
+
+func shouldReportAsInfo() {
+	_ = Metadata{Key: ""} // want "severity.Metadata is missing field Value"
+}
@@ -0,0 +1,15 @@
+package sites
+
+type Request struct {
+	Method string
+	URL    string
+}
+
+type Response struct {
+	Code int
+}
+
+func literals() {
+	_ = Request{}  // want "sites.Request is missing fields Method, URL"
+	_ = Response{} // want "sites.Response is missing field Code"
+}
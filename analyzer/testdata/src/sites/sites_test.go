@@ -0,0 +1,9 @@
+package sites
+
+import "testing"
+
+func TestLiterals(t *testing.T) {
+	_ = Request{}
+	_ = Request{Method: ""} // want "sites.Request is missing field URL"
+	_ = Response{}          // want "sites.Response is missing field Code"
+}
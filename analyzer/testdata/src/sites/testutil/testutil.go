@@ -0,0 +1,7 @@
+package testutil
+
+import "sites"
+
+func NewRequest() sites.Request {
+	return sites.Request{}
+}
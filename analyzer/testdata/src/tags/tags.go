@@ -0,0 +1,17 @@
+package tags
+
+type Config struct {
+	Name    string `json:"name" validate:"required"`
+	Comment string `json:"comment,omitempty"`
+	Port    int    `default:"8080"`
+	Token   string `json:"token,omitempty" validate:"required"`
+	Owner   string `lint:"optional"`
+	Legacy  string `exhaustruct:"optional"`
+	Empty   bool   `json:"omitempty"`
+}
+
+func literals() {
+	_ = Config{Name: "", Token: "", Legacy: "", Empty: false}
+	_ = Config{Name: "", Empty: false} // want "tags.Config is missing fields Token, Legacy"
+	_ = Config{}                       // want "tags.Config is missing fields Name, Token, Legacy, Empty"
+}
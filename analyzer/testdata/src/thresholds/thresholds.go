@@ -0,0 +1,58 @@
+package thresholds
+
+type WideOptions struct {
+	A string
+	B string
+	C string
+	D string
+	E string `exhaustruct:"optional"`
+}
+
+type HalfSet struct {
+	A string
+	B string
+	C string
+	D string
+}
+
+type AnyField struct {
+	A string
+	B string
+	C string
+}
+
+type Strict struct {
+	A string
+	B string
+}
+
+func shouldPassMaxMissing() {
+	_ = WideOptions{A: ""}
+	_ = WideOptions{A: "", B: ""}
+}
+
+func shouldFailMaxMissing() {
+	_ = WideOptions{E: ""} // want "thresholds.WideOptions is missing fields A, B, C, D"
+	_ = WideOptions{}      // want "thresholds.WideOptions is missing fields A, B, C, D"
+}
+
+func shouldPassMinSetPercent() {
+	_ = HalfSet{A: "", B: ""}
+	_ = HalfSet{A: "", B: "", C: ""}
+}
+
+func shouldFailMinSetPercent() {
+	_ = HalfSet{A: ""} // want "thresholds.HalfSet is missing fields B, C, D"
+}
+
+func shouldPassNonEmpty() {
+	_ = AnyField{B: ""}
+}
+
+func shouldFailNonEmpty() {
+	_ = AnyField{} // want "thresholds.AnyField is missing fields A, B, C"
+}
+
+func shouldFailStrict() {
+	_ = Strict{A: ""} // want "thresholds.Strict is missing field B"
+}
@@ -0,0 +1,111 @@
+package type_names
+
+type Config struct {
+	HTTP struct {
+		Timeout int
+		Retries int
+	}
+	Items []struct {
+		A string
+		B string
+	}
+}
+
+type Server struct{}
+
+type Test struct {
+	A string
+	B int
+}
+
+func handler() {
+	_ = struct { // want "type_names.handler.<anonymous> is missing field B"
+		A string
+		B int
+	}{A: ""}
+}
+
+func (s *Server) handle() {
+	_ = struct { // want "type_names.Server.handle.<anonymous> is missing field B"
+		A string
+		B int
+	}{A: ""}
+}
+
+func fieldScoped() {
+	_ = Config{
+		HTTP: struct { // want "type_names.Config.HTTP.<anonymous> is missing field Retries"
+			Timeout int
+			Retries int
+		}{Timeout: 1},
+		Items: []struct {
+			A string
+			B string
+		}{
+			{A: ""}, // want "type_names.Config.Items.<anonymous> is missing field B"
+		},
+	}
+
+	_ = struct {
+		Inner struct {
+			A string
+			B int
+		}
+	}{
+		Inner: struct { // want "type_names.fieldScoped.Inner.<anonymous> is missing field B"
+			A string
+			B int
+		}{A: ""},
+	}
+}
+
+func run() {
+	type Test struct {
+		A string
+		B int
+	}
+
+	_ = Test{A: ""} // want "type_names.run.Test is missing field B"
+}
+
+func excludedFunc() {
+	type Test struct {
+		A string
+		B int
+	}
+
+	_ = Test{}
+	_ = struct{ A string }{}
+}
+
+func shouldFailPackageLevel() {
+	_ = Test{A: ""} // want "type_names.Test is missing field B"
+}
+
+var _ = struct{ A string }{} // want "type_names.<anonymous> is missing field A"
+
+var varHandler = func() {
+	type Test struct {
+		A string
+		B int
+	}
+
+	_ = Test{A: ""} // want "type_names.varHandler.Test is missing field B"
+
+	_ = struct { // want "type_names.varHandler.<anonymous> is missing field B"
+		A string
+		B int
+	}{A: ""}
+}
+
+var (
+	first, excludedVar = func() {}, func() {
+		type Test struct {
+			A string
+			B int
+		}
+
+		_ = Test{}
+		_ = struct{ A string }{}
+	}
+)
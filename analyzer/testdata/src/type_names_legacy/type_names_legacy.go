@@ -0,0 +1,42 @@
+package type_names_legacy
+
+type Config struct {
+	HTTP struct {
+		Timeout int
+		Retries int
+	}
+}
+
+type Test struct {
+	A string
+	B int
+}
+
+func shouldPassLegacyAnonymousName() {
+	_ = struct{ A string }{}
+
+	_ = Config{
+		HTTP: struct {
+			Timeout int
+			Retries int
+		}{},
+	}
+}
+
+func shouldPassLegacyLocalName() {
+	type Local struct {
+		A string
+		B int
+	}
+
+	_ = Local{}
+}
+
+func shouldFailNotMatchingNames() {
+	type Test struct {
+		A string
+		B int
+	}
+
+	_ = Test{A: ""} // want "type_names_legacy.shouldFailNotMatchingNames.Test is missing field B"
+}
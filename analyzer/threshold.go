@@ -0,0 +1,156 @@
+package analyzer
+
+import (
+	"strconv"
+	"strings"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+
+	"dev.gaijin.team/go/exhaustruct/v4/internal/pattern"
+)
+
+// ThresholdMode defines how [Threshold] relaxes reporting of partially
+// initialized literals.
+type ThresholdMode string
+
+const (
+	// ThresholdMaxMissing reports literal only if more than Value required
+	// fields are missing.
+	ThresholdMaxMissing ThresholdMode = "max-missing"
+
+	// ThresholdMinSetPercent reports literal only if less than Value percent of
+	// required fields are set.
+	ThresholdMinSetPercent ThresholdMode = "min-set-percent"
+
+	// ThresholdNonEmpty reports literal only if none of its fields are set.
+	ThresholdNonEmpty ThresholdMode = "non-empty"
+)
+
+// Threshold relaxes reporting of partially initialized literals of types
+// matching TypeRx.
+type Threshold struct {
+	// TypeRx is a regular expression to match type names threshold applies to.
+	//
+	// Regular expression must match the full type name, including package path.
+	TypeRx  string
+	pattern pattern.List `exhaustruct:"optional"`
+
+	Mode ThresholdMode
+
+	// Value is a mode-specific threshold value, unused by [ThresholdNonEmpty].
+	Value int `exhaustruct:"optional"`
+}
+
+// ParseThreshold parses threshold from string in form of
+// `<type-rx>=<mode>[:<value>]`, e.g. `.*Options=max-missing:2`.
+func ParseThreshold(str string) (Threshold, error) {
+	idx := strings.LastIndex(str, "=")
+	if idx == -1 {
+		return Threshold{}, e.New("threshold must be in form of <type-rx>=<mode>[:<value>]",
+			fields.F("threshold", str))
+	}
+
+	t := Threshold{
+		TypeRx: str[:idx],
+		Mode:   "",
+		Value:  0,
+	}
+
+	mode, value, hasValue := strings.Cut(str[idx+1:], ":")
+	t.Mode = ThresholdMode(mode)
+
+	if hasValue {
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return Threshold{}, e.NewFrom("parse threshold value", err, fields.F("threshold", str))
+		}
+
+		t.Value = v
+	}
+
+	return t, nil
+}
+
+// prepare validates threshold and compiles its pattern.
+func (t *Threshold) prepare() error {
+	var err error
+
+	switch t.Mode {
+	case ThresholdMaxMissing, ThresholdNonEmpty:
+	case ThresholdMinSetPercent:
+		if t.Value < 0 || t.Value > 100 {
+			return e.New("percentage must be in range [0, 100]", fields.F("value", t.Value))
+		}
+
+	default:
+		return e.New("unknown threshold mode", fields.F("mode", t.Mode))
+	}
+
+	if t.Value < 0 {
+		return e.New("threshold value must not be negative", fields.F("value", t.Value))
+	}
+
+	t.pattern, err = pattern.NewList(t.TypeRx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// allows checks whether a literal with given amount of missing fields out of
+// required ones should not be reported.
+func (t *Threshold) allows(missing, required int, empty bool) bool {
+	switch t.Mode {
+	case ThresholdMaxMissing:
+		return missing <= t.Value
+
+	case ThresholdMinSetPercent:
+		return (required-missing)*100 >= t.Value*required //nolint:mnd
+
+	case ThresholdNonEmpty:
+		return !empty
+
+	default:
+		return false
+	}
+}
+
+// String returns threshold in the same form it is parsed by [ParseThreshold].
+func (t Threshold) String() string {
+	if t.Mode == ThresholdNonEmpty {
+		return t.TypeRx + "=" + string(t.Mode)
+	}
+
+	return t.TypeRx + "=" + string(t.Mode) + ":" + strconv.Itoa(t.Value)
+}
+
+// thresholdsFlag implements flag.Value interface for []Threshold fields.
+type thresholdsFlag struct {
+	slice *[]Threshold
+}
+
+func (f thresholdsFlag) String() string {
+	if f.slice == nil {
+		return ""
+	}
+
+	s := make([]string, 0, len(*f.slice))
+	for _, t := range *f.slice {
+		s = append(s, t.String())
+	}
+
+	return strings.Join(s, ",")
+}
+
+func (f thresholdsFlag) Set(value string) error {
+	t, err := ParseThreshold(value)
+	if err != nil {
+		return err
+	}
+
+	*f.slice = append(*f.slice, t)
+
+	return nil
+}
@@ -0,0 +1,273 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// anonymousName is a name of anonymous structures, which is qualified by the
+// place structure is used in, e.g. `Config.HTTP.<anonymous>`.
+const anonymousName = "<anonymous>"
+
+// getStructType returns a structure type of the composite literal on top of
+// the stack along with its name.
+//
+// Types declared inside functions are qualified by function name, e.g.
+// `pkg.run.localType`, while anonymous structures are qualified either by the
+// field they are value of, e.g. `pkg.Config.HTTP.<anonymous>`, or by the
+// function they are used in, e.g. `pkg.handler.<anonymous>`.
+func getStructType(pass *analysis.Pass, stack []ast.Node) (*types.Struct, *TypeInfo, bool) {
+	lit, ok := stack[len(stack)-1].(*ast.CompositeLit)
+	if !ok {
+		return nil, nil, false
+	}
+
+	litTyp := pass.TypesInfo.TypeOf(lit)
+
+	switch typ := types.Unalias(litTyp).(type) {
+	case *types.Named: // named type
+		if structTyp, ok := typ.Underlying().(*types.Struct); ok {
+			ti := newTypeInfo(pass, typ.Obj())
+			ti.Alias = getAliasInfo(pass, litTyp)
+
+			return structTyp, &ti, true
+		}
+
+		return nil, nil, false
+
+	case *types.Struct: // anonymous struct
+		ti := getAnonymousStructInfo(pass, stack)
+		ti.Alias = getAliasInfo(pass, litTyp)
+
+		return typ, &ti, true
+
+	default:
+		return nil, nil, false
+	}
+}
+
+// newTypeInfo returns a type info of a given type name.
+func newTypeInfo(pass *analysis.Pass, obj *types.TypeName) TypeInfo {
+	return TypeInfo{
+		Name:        getTypeName(pass, obj),
+		PackageName: obj.Pkg().Name(),
+		PackagePath: obj.Pkg().Path(),
+		Alias:       nil,
+		pos:         obj.Pos(),
+		legacyName:  obj.Pkg().Path() + "." + obj.Name(),
+	}
+}
+
+// getAliasInfo returns a type info of a type alias, in case given type is an
+// alias. Only the outermost alias is taken into account, as it is the one
+// used at literal site.
+func getAliasInfo(pass *analysis.Pass, typ types.Type) *TypeInfo {
+	alias, ok := typ.(*types.Alias)
+	if !ok || alias.Obj().Pkg() == nil {
+		return nil
+	}
+
+	ti := newTypeInfo(pass, alias.Obj())
+
+	return &ti
+}
+
+// getTypeName returns a name of a type, qualified by function name in case
+// type is declared inside a function. Types declared inside function literals
+// assigned to package-level variables are qualified by variable name.
+func getTypeName(pass *analysis.Pass, obj *types.TypeName) string {
+	if obj.Pkg() != pass.Pkg || obj.Parent() == nil || obj.Parent() == obj.Pkg().Scope() {
+		return obj.Name()
+	}
+
+	for _, f := range pass.Files {
+		if f.FileStart > obj.Pos() || obj.Pos() > f.FileEnd {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			if decl.Pos() > obj.Pos() || obj.Pos() >= decl.End() {
+				continue
+			}
+
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				return getFuncDeclName(d) + "." + obj.Name()
+			case *ast.GenDecl:
+				if name, ok := getValueSpecName(d, obj.Pos()); ok {
+					return name + "." + obj.Name()
+				}
+			}
+		}
+	}
+
+	return obj.Name()
+}
+
+// hasFuncLit checks whether any node of the stack is a function literal.
+func hasFuncLit(stack []ast.Node) bool {
+	for _, n := range stack {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getValueSpecName returns a name of a package-level variable or constant, a
+// value of which contains a given position.
+func getValueSpecName(gd *ast.GenDecl, pos token.Pos) (string, bool) {
+	for _, spec := range gd.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+
+		for i, v := range vs.Values {
+			if v.Pos() <= pos && pos < v.End() && i < len(vs.Names) {
+				return vs.Names[i].Name, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// getAnonymousStructInfo returns a type info of anonymous structure literal on
+// top of the stack. Pointer taking, parentheses and elements of slices, arrays
+// and maps are skipped while looking for the field structure is a value of.
+func getAnonymousStructInfo(pass *analysis.Pass, stack []ast.Node) TypeInfo {
+	i := len(stack) - 1
+
+	for i > 0 {
+		child := stack[i]
+
+		switch p := stack[i-1].(type) {
+		case *ast.UnaryExpr:
+			if p.Op != token.AND {
+				return getFuncScopedAnonymousInfo(pass, stack)
+			}
+
+			i--
+
+			continue
+
+		case *ast.ParenExpr:
+			i--
+
+			continue
+
+		case *ast.KeyValueExpr:
+			if p.Value != child || i < 2 {
+				return getFuncScopedAnonymousInfo(pass, stack)
+			}
+
+			if key, ok := p.Key.(*ast.Ident); ok {
+				if ti, ok := getFieldScopedAnonymousInfo(pass, stack[:i-1], key.Name); ok {
+					return ti
+				}
+			}
+
+			// element of map, slice or array literal
+			i -= 2
+
+			continue
+
+		case *ast.CompositeLit:
+			for idx, elt := range p.Elts {
+				if elt != child {
+					continue
+				}
+
+				// unkeyed structure literal, field is defined by element position
+				if st, ok := pass.TypesInfo.TypeOf(p).Underlying().(*types.Struct); ok && idx < st.NumFields() {
+					if ti, ok := getFieldScopedAnonymousInfo(pass, stack[:i], st.Field(idx).Name()); ok {
+						return ti
+					}
+				}
+			}
+
+			i--
+
+			continue
+		}
+
+		break
+	}
+
+	return getFuncScopedAnonymousInfo(pass, stack)
+}
+
+// getFieldScopedAnonymousInfo returns a type info of anonymous structure that
+// is a value of a given field of structure literal on top of the stack.
+func getFieldScopedAnonymousInfo(pass *analysis.Pass, stack []ast.Node, field string) (TypeInfo, bool) {
+	_, outer, ok := getStructType(pass, stack)
+	if !ok {
+		return TypeInfo{}, false //nolint:exhaustruct
+	}
+
+	return TypeInfo{
+		Name:        strings.TrimSuffix(outer.Name, "."+anonymousName) + "." + field + "." + anonymousName,
+		PackageName: outer.PackageName,
+		PackagePath: outer.PackagePath,
+		pos:         outer.pos,
+		legacyName:  pass.Pkg.Path() + "." + anonymousName,
+	}, true
+}
+
+// getFuncScopedAnonymousInfo returns a type info of anonymous structure
+// qualified by the function it is used in, if any. Function literals assigned
+// to package-level variables are qualified by variable name.
+func getFuncScopedAnonymousInfo(pass *analysis.Pass, stack []ast.Node) TypeInfo {
+	name := anonymousName
+
+	if len(stack) > 1 {
+		switch d := stack[1].(type) {
+		case *ast.FuncDecl:
+			name = getFuncDeclName(d) + "." + anonymousName
+		case *ast.GenDecl:
+			if fn, ok := getValueSpecName(d, stack[len(stack)-1].Pos()); ok && hasFuncLit(stack) {
+				name = fn + "." + anonymousName
+			}
+		}
+	}
+
+	return TypeInfo{
+		Name:        name,
+		PackageName: pass.Pkg.Name(),
+		PackagePath: pass.Pkg.Path(),
+		legacyName:  pass.Pkg.Path() + "." + anonymousName,
+	}
+}
+
+// getFuncDeclName returns a name of a function, methods are qualified by
+// receiver type name, e.g. `Server.handle`.
+func getFuncDeclName(fd *ast.FuncDecl) string {
+	if fd.Recv == nil || len(fd.Recv.List) == 0 {
+		return fd.Name.Name
+	}
+
+	typ := fd.Recv.List[0].Type
+
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+		case *ast.ParenExpr:
+			typ = t.X
+		case *ast.IndexExpr:
+			typ = t.X
+		case *ast.IndexListExpr:
+			typ = t.X
+		case *ast.Ident:
+			return t.Name + "." + fd.Name.Name
+		default:
+			return fd.Name.Name
+		}
+	}
+}
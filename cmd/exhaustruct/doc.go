@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+const (
+	docCommand = "doc"
+
+	formatMarkdown = "markdown"
+)
+
+// runDoc runs `exhaustruct doc` subcommand, which describes field requirements
+// of structures declared in given packages, under configuration set by flags.
+func runDoc(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet(docCommand, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	format := fs.String("format", formatMarkdown, "Output format: markdown or json")
+
+	var config analyzer.Config
+
+	config.BindToFlagSet(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Describes field requirements of structures declared in given packages\n\n"+
+			"Usage: exhaustruct %s [-flag] [package]\n\nFlags:\n", docCommand)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return exitFailure
+	}
+
+	if fs.NArg() == 0 || (*format != formatMarkdown && *format != formatJSON) {
+		fs.Usage()
+		return exitFailure
+	}
+
+	docs, err := describe(config, fs.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "exhaustruct %s: %s\n", docCommand, err)
+		return exitFailure
+	}
+
+	if *format == formatJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "\t")
+
+		if err := enc.Encode(docs); err != nil {
+			fmt.Fprintf(stderr, "exhaustruct %s: %s\n", docCommand, err)
+			return exitFailure
+		}
+
+		return exitOK
+	}
+
+	printMarkdown(stdout, docs)
+
+	return exitOK
+}
+
+// describe loads packages matching given patterns and describes their
+// structures.
+func describe(config analyzer.Config, patterns []string) ([]analyzer.TypeDoc, error) {
+	pkgs, err := packages.Load(&packages.Config{ //nolint:exhaustruct
+		Mode: packages.LoadAllSyntax,
+	}, patterns...)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		return nil, fmt.Errorf("%d errors during loading", n) //nolint:err113
+	}
+
+	res := make([]analyzer.TypeDoc, 0)
+
+	for _, pkg := range pkgs {
+		docs, err := analyzer.Describe(config, pkg.Types, pkg.Syntax)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		res = append(res, docs...)
+	}
+
+	return res, nil
+}
+
+func printMarkdown(w io.Writer, docs []analyzer.TypeDoc) {
+	for i, d := range docs {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		fmt.Fprintf(w, "## %s\n\n", d.Name)
+
+		if !d.Checked {
+			fmt.Fprintf(w, "Not checked: excluded by configuration.\n\n")
+		}
+
+		if len(d.Rules) > 0 {
+			fmt.Fprintf(w, "Type-level rules: %s\n\n", markdownCodeList(d.Rules))
+		}
+
+		if len(d.Directives) > 0 {
+			fmt.Fprintf(w, "Directives: %s\n\n", markdownCodeList(d.Directives))
+		}
+
+		if len(d.Fields) == 0 {
+			fmt.Fprintf(w, "No fields.\n")
+			continue
+		}
+
+		fmt.Fprintf(w, "| Field | Type | Requirement |\n|-------|------|-------------|\n")
+
+		for _, f := range d.Fields {
+			name := "`" + f.Name + "`"
+			if f.Embedded {
+				name += " (embedded)"
+			}
+
+			req := string(f.Requirement)
+			if f.NonZero {
+				req += ", non-zero"
+			}
+
+			fmt.Fprintf(w, "| %s | `%s` | %s |\n", name, strings.ReplaceAll(f.Type, "|", `\|`), req)
+		}
+	}
+}
+
+func markdownCodeList(items []string) string {
+	res := make([]string, len(items))
+	for i, item := range items {
+		res[i] = "`" + item + "`"
+	}
+
+	return strings.Join(res, ", ")
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestRunDoc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("markdown", func(t *testing.T) {
+		t.Parallel()
+
+		var stdout, stderr bytes.Buffer
+
+		exitCode := runDoc([]string{"-e", `.*\.Base`, "./testdata/src/doc"}, &stdout, &stderr)
+		require.Equal(t, exitOK, exitCode, stderr.String())
+
+		out := stdout.String()
+		assert.Contains(t, out, "## dev.gaijin.team/go/exhaustruct/v4/cmd/exhaustruct/testdata/src/doc.Config\n")
+		assert.Contains(t, out, "Directives: `//exhaustruct:gen`\n")
+		assert.Contains(t, out, "Type-level rules: `severity=error`\n")
+		assert.Contains(t, out, "| `Base` (embedded) | `Base` | required |\n")
+		assert.Contains(t, out, "| `Name` | `string` | required, non-zero |\n")
+		assert.Contains(t, out, "| `Timeout` | `time.Duration` | optional |\n")
+		assert.Contains(t, out, "| `secret` | `string` | package-only |\n")
+		assert.Contains(t, out, "Not checked: excluded by configuration.\n")
+		assert.NotContains(t, out, "NotStruct")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var stdout, stderr bytes.Buffer
+
+		exitCode := runDoc([]string{"-format", "json", "./testdata/src/doc"}, &stdout, &stderr)
+		require.Equal(t, exitOK, exitCode, stderr.String())
+
+		var docs []analyzer.TypeDoc
+
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &docs))
+		require.Len(t, docs, 3)
+		assert.Equal(t, "dev.gaijin.team/go/exhaustruct/v4/cmd/exhaustruct/testdata/src/doc.Options", docs[2].Name)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		t.Parallel()
+
+		var stdout, stderr bytes.Buffer
+
+		exitCode := runDoc([]string{"-format", "yaml", "./testdata/src/doc"}, &stdout, &stderr)
+		assert.Equal(t, exitFailure, exitCode)
+	})
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/token"
+	"io"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+const (
+	exitOK          = 0
+	exitFailure     = 1
+	exitDiagnostics = 3
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// finding is an [analyzer.Finding] with resolved position, as it is printed.
+type finding struct {
+	pos token.Position
+
+	Posn     string `json:"posn"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	Rule     string `json:"rule"`
+	URL      string `json:"url"`
+}
+
+// run runs the analyzer over packages listed in args and prints findings.
+// Exit code is [exitDiagnostics] only in case at least one finding has
+// [analyzer.SeverityError], so warnings and info do not break the build.
+func run(a *analysis.Analyzer, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet(a.Name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	format := fs.String("format", formatText, "Output format: text or json")
+	jsonFormat := fs.Bool("json", false, "Short form of -format json")
+	tests := fs.Bool("test", true, "Indicates whether test files should be analyzed, too")
+
+	a.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "%s: %s\n\nUsage: %s [-flag] [package]\n\nFlags:\n", a.Name, a.Doc, a.Name)
+		fs.PrintDefaults()
+	}
+
+	if name, ok := findUnsupportedFlag(args); ok {
+		fmt.Fprintf(stderr, "%s: flag -%s of the standard analysis driver is not supported, "+
+			"run %s with go vet -vettool to use it\n", a.Name, name, a.Name)
+		return exitFailure
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return exitFailure
+	}
+
+	if *jsonFormat {
+		*format = formatJSON
+	}
+
+	if fs.NArg() == 0 || (*format != formatText && *format != formatJSON) {
+		fs.Usage()
+		return exitFailure
+	}
+
+	findings, err := analyze(a, fs.Args(), *tests)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %s\n", a.Name, err)
+		return exitFailure
+	}
+
+	if err := printFindings(stdout, *format, findings); err != nil {
+		fmt.Fprintf(stderr, "%s: %s\n", a.Name, err)
+		return exitFailure
+	}
+
+	for _, f := range findings {
+		if f.Severity == string(analyzer.SeverityError) {
+			return exitDiagnostics
+		}
+	}
+
+	return exitOK
+}
+
+// unsupportedFlags are flags of the standard analysis driver, that are not
+// supported by [run], as they either rely on suggested fixes, which analyzer
+// does not provide, or are meant for debugging of the driver itself.
+var unsupportedFlags = map[string]bool{ //nolint:gochecknoglobals
+	"fix":        true,
+	"diff":       true,
+	"c":          true,
+	"debug":      true,
+	"cpuprofile": true,
+	"memprofile": true,
+	"trace":      true,
+}
+
+// findUnsupportedFlag returns the name of the first flag of given arguments
+// that is not supported by [run], if any.
+func findUnsupportedFlag(args []string) (string, bool) {
+	for _, arg := range args {
+		if arg == "--" {
+			return "", false
+		}
+
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if unsupportedFlags[name] {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// analyze loads packages matching given patterns and runs the analyzer over
+// them, returning sorted and deduplicated findings.
+func analyze(a *analysis.Analyzer, patterns []string, tests bool) ([]finding, error) {
+	pkgs, err := packages.Load(&packages.Config{ //nolint:exhaustruct
+		Mode:  packages.LoadAllSyntax,
+		Tests: tests,
+	}, patterns...)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		return nil, fmt.Errorf("%d errors during loading", n) //nolint:err113
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{a}, pkgs, nil)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	seen := make(map[finding]bool)
+	res := make([]finding, 0)
+
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			return nil, act.Err
+		}
+
+		r, ok := act.Result.(*analyzer.Result)
+		if !ok {
+			continue
+		}
+
+		for _, f := range r.Findings {
+			pos := act.Package.Fset.Position(f.Diagnostic.Pos)
+			jf := finding{
+				pos:      pos,
+				Posn:     pos.String(),
+				Message:  f.Diagnostic.Message,
+				Severity: string(f.Severity),
+				Code:     f.Rule.Code,
+				Rule:     f.Rule.Name,
+				URL:      f.Diagnostic.URL,
+			}
+
+			if !seen[jf] {
+				seen[jf] = true
+				res = append(res, jf)
+			}
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return positionLess(res[i].pos, res[j].pos) })
+
+	return res, nil
+}
+
+func positionLess(a, b token.Position) bool {
+	if a.Filename != b.Filename {
+		return a.Filename < b.Filename
+	}
+
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+
+	return a.Column < b.Column
+}
+
+func printFindings(w io.Writer, format string, findings []finding) error {
+	if format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "\t")
+
+		return enc.Encode(findings) //nolint:wrapcheck
+	}
+
+	for _, f := range findings {
+		fmt.Fprintf(w, "%s: %s: %s (%s)\n", f.Posn, f.Severity, f.Message, f.Code)
+	}
+
+	return nil
+}
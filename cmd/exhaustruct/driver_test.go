@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("loads packages")
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		exitCode   int
+		severities []string
+	}{
+		{
+			name:       "errors",
+			args:       []string{"./testdata/src/flags"},
+			exitCode:   exitDiagnostics,
+			severities: []string{"error", "error", "error"},
+		},
+		{
+			name:       "warnings only",
+			args:       []string{"-severity", "warning", "./testdata/src/flags"},
+			exitCode:   exitOK,
+			severities: []string{"warning", "warning", "warning"},
+		},
+		{
+			name:       "info only",
+			args:       []string{"-severity", "info", "./testdata/src/flags"},
+			exitCode:   exitOK,
+			severities: []string{"info", "info", "info"},
+		},
+		{
+			name:       "warnings with error",
+			args:       []string{"-severity", "warning", "-error-rx", `.*\.Options`, "./testdata/src/flags"},
+			exitCode:   exitDiagnostics,
+			severities: []string{"warning", "warning", "error"},
+		},
+		{
+			name:       "json flag",
+			args:       []string{"-json", "-severity", "warning", "./testdata/src/flags"},
+			exitCode:   exitOK,
+			severities: []string{"warning", "warning", "warning"},
+		},
+		{
+			name:     "no findings",
+			args:     []string{"-e", `.*\.(Included|Excluded|Options)`, "./testdata/src/flags"},
+			exitCode: exitOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := analyzer.NewAnalyzer(analyzer.Config{}) //nolint:exhaustruct
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+
+			args := append([]string{"-format", formatJSON}, tt.args...)
+			exitCode := run(a, args, &stdout, &stderr)
+			require.Equal(t, tt.exitCode, exitCode, stderr.String())
+
+			var findings []finding
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &findings), stdout.String())
+
+			var severities []string
+			for _, f := range findings {
+				severities = append(severities, f.Severity)
+			}
+
+			assert.Equal(t, tt.severities, severities)
+		})
+	}
+}
+
+func TestRun_FormatJSON(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("loads packages")
+	}
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{}) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+
+	exitCode := run(a, []string{"-format", "json", "-i", `.*\.Included`, "./testdata/src/flags"}, &stdout, &stderr)
+	require.Equal(t, exitDiagnostics, exitCode, stderr.String())
+
+	var findings []map[string]string
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &findings), stdout.String())
+	require.Len(t, findings, 1)
+
+	posn, err := filepath.Abs(filepath.Join("testdata", "src", "flags", "flags.go"))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"posn":     posn + ":18:6",
+		"message":  "flags.Included is missing field B",
+		"severity": "error",
+		"code":     "EXS001",
+		"rule":     "missing-fields",
+		"url":      "https://github.com/GaijinEntertainment/go-exhaustruct#exs001-missing-fields",
+	}, findings[0])
+}
+
+func TestRun_InvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{}) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+
+	exitCode := run(a, []string{"-format", "xml", "./testdata/src/flags"}, &stdout, &stderr)
+	assert.Equal(t, exitFailure, exitCode)
+	assert.Empty(t, stdout.String())
+	assert.Contains(t, stderr.String(), "Usage:")
+}
+
+func TestRun_UnsupportedFlag(t *testing.T) {
+	t.Parallel()
+
+	a, err := analyzer.NewAnalyzer(analyzer.Config{}) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+
+	exitCode := run(a, []string{"-severity", "warning", "-fix", "./testdata/src/flags"}, &stdout, &stderr)
+	assert.Equal(t, exitFailure, exitCode)
+	assert.Empty(t, stdout.String())
+	assert.Contains(t, stderr.String(), "flag -fix of the standard analysis driver is not supported")
+}
+
+func TestFindUnsupportedFlag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"./..."}, want: ""},
+		{args: []string{"-format", "json", "./..."}, want: ""},
+		{args: []string{"-json", "./..."}, want: ""},
+		{args: []string{"--fix", "./..."}, want: "fix"},
+		{args: []string{"-i", `.*\.Test`, "-diff", "./..."}, want: "diff"},
+		{args: []string{"-c=2", "./..."}, want: "c"},
+		{args: []string{"-cpuprofile", "cpu.out", "./..."}, want: "cpuprofile"},
+		{args: []string{"--", "-fix"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		name, ok := findUnsupportedFlag(tt.args)
+		assert.Equal(t, tt.want, name, tt.args)
+		assert.Equal(t, tt.want != "", ok, tt.args)
+	}
+}
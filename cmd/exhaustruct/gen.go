@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"dev.gaijin.team/go/exhaustruct/v4/analyzer"
+	"dev.gaijin.team/go/exhaustruct/v4/internal/gen"
+)
+
+const genCommand = "gen"
+
+// runGen runs `exhaustruct gen` subcommand, which generates constructors of
+// structures annotated with `//exhaustruct:gen` directive. It is meant to be
+// used with `go:generate`, so the package in current directory is processed by
+// default. Analyzer flags are accepted as well, so generated constructors
+// interpret field tags the same way analyzer does.
+func runGen(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet(genCommand, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	output := fs.String("output", gen.DefaultOutput, "Name of generated file")
+
+	var config analyzer.Config
+
+	config.BindToFlagSet(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Generates constructors of structures annotated with //exhaustruct:gen directive\n\n"+
+			"Usage: exhaustruct %s [-flag] [directory]\n\nFlags:\n", genCommand)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return exitFailure
+	}
+
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return exitFailure
+	}
+
+	dir := "."
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+
+	tags, err := analyzer.FieldTags(config)
+	if err != nil {
+		fmt.Fprintf(stderr, "exhaustruct %s: %s\n", genCommand, err)
+		return exitFailure
+	}
+
+	src, ok, err := gen.Generate(dir, *output, tags)
+	if err != nil {
+		fmt.Fprintf(stderr, "exhaustruct %s: %s\n", genCommand, err)
+		return exitFailure
+	}
+
+	if !ok {
+		fmt.Fprintf(stderr, "exhaustruct %s: no annotated structures found in %s\n", genCommand, dir)
+		return exitOK
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, *output), src, 0o644); err != nil { //nolint:gosec,mnd
+		fmt.Fprintf(stderr, "exhaustruct %s: %s\n", genCommand, err)
+		return exitFailure
+	}
+
+	return exitOK
+}
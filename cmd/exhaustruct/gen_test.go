@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunGen(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	output := "exhaustruct_gen_test_output.go"
+
+	fixture, err := os.ReadFile(filepath.Join("testdata", "src", "gen", "gen.go"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gen.go"), fixture, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module gen\n\ngo 1.24\n"), 0o600))
+
+	var stderr bytes.Buffer
+
+	exitCode := runGen([]string{"-output", output, dir}, &stderr)
+	require.Equal(t, exitOK, exitCode, stderr.String())
+
+	src, err := os.ReadFile(filepath.Join(dir, output))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(src), "// Code generated by exhaustruct gen. DO NOT EDIT.")
+	assert.Contains(t, string(src), "func NewTest(a string) Test {")
+	assert.Contains(t, string(src), "func NewTagged(name string, comment string) Tagged {")
+
+	// generated file is ignored on subsequent runs
+	exitCode = runGen([]string{"-output", output, dir}, &stderr)
+	require.Equal(t, exitOK, exitCode, stderr.String())
+}
+
+func TestRunGen_Tags(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	fixture, err := os.ReadFile(filepath.Join("testdata", "src", "gen", "gen.go"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gen.go"), fixture, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module gen\n\ngo 1.24\n"), 0o600))
+
+	var stderr bytes.Buffer
+
+	exitCode := runGen([]string{"-optional-tag", "json:omitempty", dir}, &stderr)
+	require.Equal(t, exitOK, exitCode, stderr.String())
+
+	src, err := os.ReadFile(filepath.Join(dir, "exhaustruct_gen.go"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(src), "func NewTagged(name string) Tagged {")
+}
+
+func TestRunGen_InvalidTags(t *testing.T) {
+	t.Parallel()
+
+	var stderr bytes.Buffer
+
+	exitCode := runGen([]string{"-optional-tag", ":omitempty", t.TempDir()}, &stderr)
+	assert.Equal(t, exitFailure, exitCode)
+	assert.Contains(t, stderr.String(), "parse optional tag")
+}
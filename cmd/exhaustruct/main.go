@@ -2,6 +2,9 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"os"
+	"strings"
 
 	"golang.org/x/tools/go/analysis/singlechecker"
 
@@ -9,12 +12,42 @@ import (
 )
 
 func main() {
-	flag.Bool("unsafeptr", false, "")
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case genCommand:
+			os.Exit(runGen(os.Args[2:], os.Stderr))
+		case docCommand:
+			os.Exit(runDoc(os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
+	// `go test` runs vet tool with -unsafeptr=false, which is a flag of the
+	// standard vet analyzers, so it must be accepted though it is not used.
+	flag.Bool("unsafeptr", false, "Unused, accepted for compatibility with go test")
 
 	a, err := analyzer.NewAnalyzer(analyzer.Config{})
 	if err != nil {
-		panic(err)
+		fmt.Fprintf(os.Stderr, "exhaustruct: %s\n", err)
+		os.Exit(exitFailure)
+	}
+
+	// go vet passes its own protocol flags and a config file, which are only
+	// supported by the standard driver, in such case we delegate everything to
+	// it.
+	if isVetInvocation(os.Args[1:]) {
+		singlechecker.Main(a)
+	}
+
+	os.Exit(run(a, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// isVetInvocation checks whether the binary is invoked by `go vet -vettool`.
+func isVetInvocation(args []string) bool {
+	for _, arg := range args {
+		if arg == "-flags" || strings.HasPrefix(arg, "-V") {
+			return true
+		}
 	}
 
-	singlechecker.Main(a)
+	return len(args) > 0 && strings.HasSuffix(args[len(args)-1], ".cfg")
 }
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain_Flags(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("builds binary")
+	}
+
+	bin := filepath.Join(t.TempDir(), "exhaustruct")
+
+	out, err := exec.Command("go", "build", "-o", bin, ".").CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	tests := []struct {
+		name     string
+		args     []string
+		exitCode int
+		stdout   string
+		stderr   string
+
+		// stdoutContains is checked instead of stdout, in case exact output is
+		// not relevant.
+		stdoutContains string
+	}{
+		{
+			name:     "no flags",
+			args:     []string{"./testdata/src/flags"},
+			exitCode: exitDiagnostics,
+			stdout: "flags.go:18:6: error: flags.Included is missing field B (EXS001)\n" +
+				"flags.go:19:6: error: flags.Excluded is missing field B (EXS001)\n" +
+				"flags.go:20:6: error: flags.Options is missing field A (EXS002)\n",
+		},
+		{
+			name: "patterns are compiled",
+			args: []string{
+				"-i", `.*\.Included`, "-i", `.*\.Options`,
+				"-allow-empty-rx", `.*\.Options`,
+				"./testdata/src/flags",
+			},
+			exitCode: exitDiagnostics,
+			stdout:   "flags.go:18:6: error: flags.Included is missing field B (EXS001)\n",
+		},
+		{
+			name:     "exclude pattern is compiled",
+			args:     []string{"-e", `.*\.(Included|Excluded|Options)`, "./testdata/src/flags"},
+			exitCode: exitOK,
+		},
+		{
+			name:           "json flag",
+			args:           []string{"-json", "-i", `.*\.Included`, "./testdata/src/flags"},
+			exitCode:       exitDiagnostics,
+			stdoutContains: `"severity": "error"`,
+		},
+		{
+			name:     "unsupported standard driver flag",
+			args:     []string{"-fix", "./testdata/src/flags"},
+			exitCode: exitFailure,
+			stderr:   "exhaustruct: flag -fix of the standard analysis driver is not supported",
+		},
+		{
+			name:     "invalid pattern",
+			args:     []string{"-i", `(`, "./testdata/src/flags"},
+			exitCode: exitFailure,
+			stderr:   "exhaustruct: invalid configuration: compile include patterns",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			cmd := exec.Command(bin, tt.args...)
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			exitCode := 0
+
+			var exitErr *exec.ExitError
+			if err := cmd.Run(); errors.As(err, &exitErr) {
+				exitCode = exitErr.ExitCode()
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.exitCode, exitCode, stderr.String())
+			assert.Contains(t, stderr.String(), tt.stderr)
+
+			if tt.stdoutContains != "" {
+				assert.Contains(t, stdout.String(), tt.stdoutContains)
+				return
+			}
+
+			if tt.stdout == "" {
+				assert.Empty(t, stdout.String())
+				return
+			}
+
+			// positions are printed with absolute paths
+			lines := bytes.SplitAfter(stdout.Bytes(), []byte("\n"))
+			for i := range lines {
+				if j := bytes.LastIndex(lines[i], []byte("/flags.go")); j >= 0 {
+					lines[i] = lines[i][j+1:]
+				}
+			}
+
+			assert.Equal(t, tt.stdout, string(bytes.Join(lines, nil)))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package doc
+
+import "time"
+
+type Base struct {
+	ID int
+}
+
+// Config is a configuration.
+//
+//exhaustruct:gen
+type Config struct {
+	Base
+
+	Name    string        `exhaustruct:"nonzero"`
+	Timeout time.Duration `exhaustruct:"optional"`
+	secret  string
+}
+
+type Options struct {
+	Filter func(string) bool
+}
+
+type NotStruct int
@@ -0,0 +1,21 @@
+package flags
+
+type Included struct {
+	A string
+	B int
+}
+
+type Excluded struct {
+	A string
+	B int
+}
+
+type Options struct {
+	A string
+}
+
+func literals() {
+	_ = Included{A: ""}
+	_ = Excluded{A: ""}
+	_ = Options{}
+}
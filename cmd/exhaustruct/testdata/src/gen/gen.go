@@ -0,0 +1,13 @@
+package gen
+
+//exhaustruct:gen
+type Test struct {
+	A string
+	B int `exhaustruct:"optional"`
+}
+
+//exhaustruct:gen
+type Tagged struct {
+	Name    string `json:"name"`
+	Comment string `json:"comment,omitempty"`
+}
@@ -8,17 +8,88 @@ import (
 type Directive string
 
 const (
-	prefix                     = `//exhaustruct:`
-	DirectiveIgnore  Directive = prefix + `ignore`
-	DirectiveEnforce Directive = prefix + `enforce`
+	prefix                         = `//exhaustruct:`
+	DirectiveIgnore      Directive = prefix + `ignore`
+	DirectiveEnforce     Directive = prefix + `enforce`
+	DirectiveSkip        Directive = prefix + `skip`
+	DirectiveConfig      Directive = prefix + `config`
+	DirectiveGen         Directive = prefix + `gen`
+	DirectiveConstructor Directive = prefix + `constructor`
 )
 
+// knownDirectives is a list of all directives supported by the analyzer.
+//
+//nolint:gochecknoglobals
+var knownDirectives = []Directive{
+	DirectiveIgnore,
+	DirectiveEnforce,
+	DirectiveSkip,
+	DirectiveConfig,
+	DirectiveGen,
+	DirectiveConstructor,
+}
+
 // HasDirective parses a directive from a given list of comments.
 // If no directive is found, the second return value is `false`.
 func HasDirective(comments []*ast.CommentGroup, expected Directive) bool {
 	for _, cg := range comments {
 		for _, commentLine := range cg.List {
-			if strings.HasPrefix(commentLine.Text, string(expected)) {
+			if directiveName(commentLine.Text) == string(expected) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// FindDirectives returns all comments from a given list that contain a given
+// directive.
+func FindDirectives(comments []*ast.CommentGroup, expected Directive) []*ast.Comment {
+	var res []*ast.Comment
+
+	for _, cg := range comments {
+		for _, commentLine := range cg.List {
+			if directiveName(commentLine.Text) == string(expected) {
+				res = append(res, commentLine)
+			}
+		}
+	}
+
+	return res
+}
+
+// DirectiveArgs returns arguments of a directive comment, i.e. whitespace
+// separated words following the directive name. Nested comment, started with
+// `//`, is not treated as a part of arguments.
+func DirectiveArgs(c *ast.Comment) []string {
+	text, _, _ := strings.Cut(c.Text[len("//"):], "//")
+	f := strings.Fields(text)
+	if len(f) < 2 { //nolint:mnd // directive name and at least one argument
+		return nil
+	}
+
+	return f[1:]
+}
+
+// directiveName returns the first word of a comment, which is a directive name
+// in case comment is a directive.
+func directiveName(text string) string {
+	if f := strings.Fields(text); len(f) > 0 {
+		return f[0]
+	}
+
+	return ""
+}
+
+// HasNolint checks whether a given list of comments contains a suppression of
+// a given linter, recognized by other tools: golangci-lint `//nolint` (bare,
+// `//nolint:all` or `//nolint:<linter>[,...]`), and staticcheck-style
+// `//lint:ignore <linter>[,...] reason`.
+func HasNolint(comments []*ast.CommentGroup, linter string) bool {
+	for _, cg := range comments {
+		for _, commentLine := range cg.List {
+			if isNolint(commentLine.Text, linter) || isLintIgnore(commentLine.Text, linter) {
 				return true
 			}
 		}
@@ -26,3 +97,80 @@ func HasDirective(comments []*ast.CommentGroup, expected Directive) bool {
 
 	return false
 }
+
+// isNolint checks whether comment is a golangci-lint suppression of a given
+// linter.
+func isNolint(text, linter string) bool {
+	rest, ok := strings.CutPrefix(text, "//nolint")
+	if !ok {
+		return false
+	}
+
+	// bare `//nolint` suppresses all linters
+	if rest == "" || rest[0] == ' ' || rest[0] == '\t' {
+		return true
+	}
+
+	list, ok := strings.CutPrefix(rest, ":")
+	if !ok {
+		return false
+	}
+
+	list, _, _ = strings.Cut(list, " ")
+
+	return containsName(list, linter) || containsName(list, "all")
+}
+
+// isLintIgnore checks whether comment is a staticcheck-style suppression of a
+// given linter.
+func isLintIgnore(text, linter string) bool {
+	rest, ok := strings.CutPrefix(text, "//lint:ignore ")
+	if !ok {
+		return false
+	}
+
+	list, _, _ := strings.Cut(strings.TrimLeft(rest, " "), " ")
+
+	return containsName(list, linter)
+}
+
+// containsName checks whether comma-separated list contains a given name.
+func containsName(list, name string) bool {
+	for _, item := range strings.Split(list, ",") {
+		if strings.TrimSpace(item) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InvalidDirectives returns all comments of a given file that start with
+// directive prefix, but do not name any known directive.
+func InvalidDirectives(f *ast.File) []*ast.Comment {
+	var res []*ast.Comment
+
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			if strings.HasPrefix(c.Text, prefix) && !isKnownDirective(c.Text) {
+				res = append(res, c)
+			}
+		}
+	}
+
+	return res
+}
+
+// isKnownDirective checks whether the first word of a comment is one of known
+// directives.
+func isKnownDirective(text string) bool {
+	name := directiveName(text)
+
+	for _, d := range knownDirectives {
+		if name == string(d) {
+			return true
+		}
+	}
+
+	return false
+}
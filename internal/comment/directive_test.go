@@ -2,9 +2,12 @@ package comment_test
 
 import (
 	"go/ast"
+	"go/parser"
+	"go/token"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"dev.gaijin.team/go/exhaustruct/v4/internal/comment"
 )
@@ -72,6 +75,20 @@ func TestParseDirective(t *testing.T) {
 			directive: comment.DirectiveEnforce,
 			found:     true,
 		},
+		{
+			name: "lookalike directive",
+			comments: []*ast.CommentGroup{
+				{
+					List: []*ast.Comment{
+						{
+							Text: "//exhaustruct:ignorance",
+						},
+					},
+				},
+			},
+			directive: comment.DirectiveIgnore,
+			found:     false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -84,3 +101,97 @@ func TestParseDirective(t *testing.T) {
 		})
 	}
 }
+
+func TestInvalidDirectives(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+//exhaustruct:ignore
+var _ = 1
+
+//exhaustruct:enforce because of some reason
+var _ = 2
+
+//exhaustruct:unknown
+var _ = 3
+
+//exhaustruct:ignoree
+var _ = 4
+
+//exhaustive:enforce
+var _ = 5
+`
+
+	f, err := parser.ParseFile(token.NewFileSet(), "p.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	invalid := comment.InvalidDirectives(f)
+
+	texts := make([]string, 0, len(invalid))
+	for _, c := range invalid {
+		texts = append(texts, c.Text)
+	}
+
+	assert.Equal(t, []string{"//exhaustruct:unknown", "//exhaustruct:ignoree"}, texts)
+}
+
+func TestHasNolint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text  string
+		found bool
+	}{
+		{text: "//nolint", found: true},
+		{text: "//nolint // some reason", found: true},
+		{text: "//nolint:exhaustruct", found: true},
+		{text: "//nolint:exhaustruct // some reason", found: true},
+		{text: "//nolint:govet,exhaustruct", found: true},
+		{text: "//nolint:all", found: true},
+		{text: "//nolint:govet", found: false},
+		{text: "//nolint:exhaustructive", found: false},
+		{text: "//nolintexhaustruct", found: false},
+		{text: "// nolint:exhaustruct", found: false},
+		{text: "//lint:ignore exhaustruct some reason", found: true},
+		{text: "//lint:ignore SA1019,exhaustruct some reason", found: true},
+		{text: "//lint:ignore SA1019 some reason", found: false},
+		{text: "//lint:file-ignore exhaustruct some reason", found: false},
+		{text: "// some comment", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			t.Parallel()
+
+			comments := []*ast.CommentGroup{{List: []*ast.Comment{{Text: tt.text}}}}
+
+			assert.Equal(t, tt.found, comment.HasNolint(comments, "exhaustruct"))
+		})
+	}
+}
+
+func TestFindDirectives(t *testing.T) {
+	t.Parallel()
+
+	comments := []*ast.CommentGroup{
+		{
+			List: []*ast.Comment{
+				{Text: "//exhaustruct:skip A,B some reason"},
+				{Text: "//exhaustruct:skipped A"},
+				{Text: "//exhaustruct:ignore"},
+			},
+		},
+		{
+			List: []*ast.Comment{
+				{Text: "//exhaustruct:skip // some reason"},
+			},
+		},
+	}
+
+	found := comment.FindDirectives(comments, comment.DirectiveSkip)
+	require.Len(t, found, 2)
+
+	assert.Equal(t, []string{"A,B", "some", "reason"}, comment.DirectiveArgs(found[0]))
+	assert.Empty(t, comment.DirectiveArgs(found[1]))
+}
@@ -0,0 +1,384 @@
+// Package gen generates constructors of structures annotated with
+// `//exhaustruct:gen` directive, so constructors are kept in sync with the
+// list of required fields.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+	"golang.org/x/tools/go/packages"
+
+	"dev.gaijin.team/go/exhaustruct/v4/internal/comment"
+	"dev.gaijin.team/go/exhaustruct/v4/internal/structure"
+)
+
+// DefaultOutput is the default name of generated file.
+const DefaultOutput = "exhaustruct_gen.go"
+
+// optionsArg is a directive argument that enables generation of functional
+// options for optional fields.
+const optionsArg = "options"
+
+// Generate loads a package from a given directory and generates constructors
+// for all structures annotated with `//exhaustruct:gen` directive. Output is a
+// name of generated file, existing file with this name is ignored while
+// loading the package.
+//
+// For every annotated structure `T` a constructor `NewT` is generated, which
+// accepts required fields as arguments, in order they appear in the
+// structure. With `//exhaustruct:gen options` directive, functional options
+// are generated for optional fields as well.
+//
+// Tags define which fields are optional, the same way analyzer interprets
+// them, so generated constructors agree with what it enforces.
+//
+// The second return value is false in case package contains no annotated
+// structures.
+func Generate(dir, output string, tags structure.Tags) ([]byte, bool, error) {
+	pkg, err := load(dir, output)
+	if err != nil {
+		return nil, false, err
+	}
+
+	g := generator{
+		pkg:     pkg.Types,
+		tags:    tags,
+		imports: make(map[string]string),
+		body:    bytes.Buffer{},
+	}
+
+	for _, f := range pkg.Syntax {
+		if filepath.Base(pkg.Fset.File(f.Pos()).Name()) == output {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gd.Specs {
+				if err := g.genSpec(pkg, gd, spec.(*ast.TypeSpec)); err != nil { //nolint:forcetypeassert
+					return nil, false, err
+				}
+			}
+		}
+	}
+
+	if g.body.Len() == 0 {
+		return nil, false, nil
+	}
+
+	src, err := format.Source(g.file())
+	if err != nil {
+		return nil, false, e.NewFrom("format generated code", err)
+	}
+
+	return src, true, nil
+}
+
+// load loads a package from a given directory. In case output file already
+// exists, it is replaced with an empty one, so outdated constructors do not
+// break type checking.
+func load(dir, output string) (*packages.Package, error) {
+	cfg := &packages.Config{ //nolint:exhaustruct
+		Mode: packages.NeedName | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax |
+			packages.NeedTypesInfo,
+		Dir: dir,
+	}
+
+	outPath, err := filepath.Abs(filepath.Join(dir, output))
+	if err != nil {
+		return nil, e.NewFrom("resolve output path", err)
+	}
+
+	if f, err := parser.ParseFile(token.NewFileSet(), outPath, nil, parser.PackageClauseOnly); err == nil {
+		cfg.Overlay = map[string][]byte{outPath: []byte("package " + f.Name.Name + "\n")}
+	} else if !os.IsNotExist(err) {
+		return nil, e.NewFrom("parse output file", err, fields.F("path", outPath))
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, e.NewFrom("load package", err, fields.F("dir", dir))
+	}
+
+	if len(pkgs) != 1 {
+		return nil, e.New("directory must contain exactly one package", fields.F("dir", dir))
+	}
+
+	if len(pkgs[0].Errors) > 0 {
+		return nil, e.New("package contains errors",
+			fields.F("dir", dir), fields.F("error", pkgs[0].Errors[0].Error()))
+	}
+
+	return pkgs[0], nil
+}
+
+type generator struct {
+	pkg  *types.Package
+	tags structure.Tags
+
+	// imports maps import path to package name used in generated code.
+	imports map[string]string
+	body    bytes.Buffer
+}
+
+// genSpec generates constructor for a given type spec, in case it is
+// annotated.
+func (g *generator) genSpec(pkg *packages.Package, gd *ast.GenDecl, spec *ast.TypeSpec) error {
+	var directives []*ast.Comment
+
+	for _, doc := range []*ast.CommentGroup{spec.Doc, gd.Doc} {
+		if doc != nil {
+			directives = append(directives, comment.FindDirectives([]*ast.CommentGroup{doc}, comment.DirectiveGen)...)
+		}
+	}
+
+	if len(directives) == 0 {
+		return nil
+	}
+
+	withOptions := false
+
+	for _, arg := range comment.DirectiveArgs(directives[0]) {
+		if arg != optionsArg {
+			return e.New("unknown directive argument",
+				fields.F("type", spec.Name.Name), fields.F("argument", arg))
+		}
+
+		withOptions = true
+	}
+
+	named, ok := pkg.TypesInfo.Defs[spec.Name].Type().(*types.Named)
+	if !ok {
+		return e.New("annotated type must be a defined type", fields.F("type", spec.Name.Name))
+	}
+
+	strct, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return e.New("annotated type must be a structure", fields.F("type", spec.Name.Name))
+	}
+
+	g.genConstructor(named, strct, withOptions)
+
+	return nil
+}
+
+// genConstructor generates constructor and, in case withOptions is true,
+// functional options of a given structure.
+func (g *generator) genConstructor(named *types.Named, strct *types.Struct, withOptions bool) {
+	name := named.Obj().Name()
+	tparams, targs := g.typeParams(named)
+	typ := name + targs
+
+	exported := named.Obj().Exported()
+	ctor := identifier(exported, "New", upperFirst(name))
+	optType := identifier(exported, upperFirst(name), "Option")
+
+	var (
+		required []*types.Var
+		optional []*types.Var
+	)
+
+	for i, f := range structure.NewFields(strct, g.tags) {
+		if f.Name == "_" || f.Forbidden {
+			continue
+		}
+
+		if f.Optional {
+			optional = append(optional, strct.Field(i))
+		} else {
+			required = append(required, strct.Field(i))
+		}
+	}
+
+	withOptions = withOptions && len(optional) > 0
+
+	taken := map[string]bool{"opts": withOptions, "opt": withOptions, "res": withOptions}
+	params := make([]string, 0, len(required)+1)
+	elts := make([]string, 0, len(required))
+
+	for _, f := range required {
+		p := g.paramName(f.Name(), taken)
+		params = append(params, p+" "+g.typeString(f.Type()))
+		elts = append(elts, f.Name()+": "+p+",")
+	}
+
+	if withOptions {
+		params = append(params, "opts ..."+optType+targs)
+	}
+
+	fmt.Fprintf(&g.body, "// %s creates %s with all required fields initialized.\n", ctor, name)
+	fmt.Fprintf(&g.body, "func %s%s(%s) %s {\n", ctor, tparams, strings.Join(params, ", "), typ)
+
+	lit := fmt.Sprintf("%s{\n%s\n}", typ, strings.Join(elts, "\n"))
+	if len(elts) == 0 {
+		lit = typ + "{}"
+	}
+
+	if !withOptions {
+		fmt.Fprintf(&g.body, "return %s\n}\n\n", lit)
+		return
+	}
+
+	fmt.Fprintf(&g.body, "res := %s\n\nfor _, opt := range opts {\nopt(&res)\n}\n\nreturn res\n}\n\n", lit)
+
+	fmt.Fprintf(&g.body, "// %s sets optional fields of %s.\n", optType, name)
+	fmt.Fprintf(&g.body, "type %s%s func(*%s)\n\n", optType, tparams, typ)
+
+	for _, f := range optional {
+		p := g.paramName(f.Name(), map[string]bool{"v": true})
+		opt := identifier(exported, "With", upperFirst(name), upperFirst(f.Name()))
+
+		fmt.Fprintf(&g.body, "// %s sets optional field %s of %s.\n", opt, f.Name(), name)
+		fmt.Fprintf(&g.body, "func %s%s(%s %s) %s%s {\n", opt, tparams, p, g.typeString(f.Type()), optType, targs)
+		fmt.Fprintf(&g.body, "return func(v *%s) {\nv.%s = %s\n}\n}\n\n", typ, f.Name(), p)
+	}
+}
+
+// typeParams returns type parameters declaration and type arguments of a given
+// generic type, e.g. `[K comparable, V any]` and `[K, V]`.
+func (g *generator) typeParams(named *types.Named) (string, string) {
+	tps := named.TypeParams()
+	if tps.Len() == 0 {
+		return "", ""
+	}
+
+	decl := make([]string, 0, tps.Len())
+	args := make([]string, 0, tps.Len())
+
+	for i := range tps.Len() {
+		tp := tps.At(i)
+		decl = append(decl, tp.Obj().Name()+" "+g.typeString(tp.Constraint()))
+		args = append(args, tp.Obj().Name())
+	}
+
+	return "[" + strings.Join(decl, ", ") + "]", "[" + strings.Join(args, ", ") + "]"
+}
+
+// typeString returns a type as it should be written in generated code,
+// registering imports of packages it refers to.
+func (g *generator) typeString(typ types.Type) string {
+	return types.TypeString(typ, func(p *types.Package) string {
+		if p == g.pkg {
+			return ""
+		}
+
+		if name, ok := g.imports[p.Path()]; ok {
+			return name
+		}
+
+		name := p.Name()
+		for g.isImportNameTaken(name) {
+			name += "_"
+		}
+
+		g.imports[p.Path()] = name
+
+		return name
+	})
+}
+
+func (g *generator) isImportNameTaken(name string) bool {
+	for _, n := range g.imports {
+		if n == name {
+			return true
+		}
+	}
+
+	return g.pkg.Scope().Lookup(name) != nil
+}
+
+// file returns unformatted source of generated file.
+func (g *generator) file() []byte {
+	var b bytes.Buffer
+
+	b.WriteString("// Code generated by exhaustruct gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", g.pkg.Name())
+
+	if len(g.imports) > 0 {
+		paths := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			paths = append(paths, path)
+		}
+
+		sort.Strings(paths)
+
+		b.WriteString("import (\n")
+
+		for _, path := range paths {
+			if name := g.imports[path]; name != filepath.Base(path) {
+				fmt.Fprintf(&b, "%s %q\n", name, path)
+			} else {
+				fmt.Fprintf(&b, "%q\n", path)
+			}
+		}
+
+		b.WriteString(")\n\n")
+	}
+
+	b.Write(g.body.Bytes())
+
+	return b.Bytes()
+}
+
+// paramName returns a name of constructor parameter for a given field name,
+// which does not clash with keywords, predeclared identifiers, package-level
+// declarations and taken names.
+func (g *generator) paramName(field string, taken map[string]bool) string {
+	r := []rune(field)
+	for i := 0; i < len(r) && unicode.IsUpper(r[i]); i++ {
+		// keep acronyms readable: ID -> id, URLPath -> urlPath
+		if i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1]) {
+			break
+		}
+
+		r[i] = unicode.ToLower(r[i])
+	}
+
+	name := string(r)
+	for token.IsKeyword(name) || types.Universe.Lookup(name) != nil ||
+		g.pkg.Scope().Lookup(name) != nil || taken[name] {
+		name += "_"
+	}
+
+	taken[name] = true
+
+	return name
+}
+
+// identifier joins given parts into an identifier, which is exported only in
+// case exported is true.
+func identifier(exported bool, parts ...string) string {
+	id := strings.Join(parts, "")
+	if exported {
+		return id
+	}
+
+	r := []rune(id)
+	r[0] = unicode.ToLower(r[0])
+
+	return string(r)
+}
+
+func upperFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+
+	return string(r)
+}
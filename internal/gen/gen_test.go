@@ -0,0 +1,91 @@
+package gen_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/exhaustruct/v4/internal/gen"
+	"dev.gaijin.team/go/exhaustruct/v4/internal/structure"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	src, ok, err := gen.Generate("testdata/src/basic", gen.DefaultOutput, structure.Tags{}) //nolint:exhaustruct
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	golden, err := os.ReadFile(filepath.Join("testdata", "src", "basic", gen.DefaultOutput))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(golden), string(src))
+}
+
+func TestGenerate_Tags(t *testing.T) {
+	t.Parallel()
+
+	src, ok, err := gen.Generate("testdata/src/tags", gen.DefaultOutput, structure.Tags{
+		Key: "lint",
+		Optional: []structure.TagRule{
+			{Key: "json", Value: "omitempty", Unnamed: false},
+			{Key: "default", Value: "", Unnamed: false},
+		},
+		Required: []structure.TagRule{{Key: "validate", Value: "required", Unnamed: true}},
+	})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	golden, err := os.ReadFile(filepath.Join("testdata", "src", "tags", gen.DefaultOutput))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(golden), string(src))
+}
+
+func TestGenerate_NoAnnotatedTypes(t *testing.T) {
+	t.Parallel()
+
+	src, ok, err := gen.Generate("testdata/src/none", gen.DefaultOutput, structure.Tags{}) //nolint:exhaustruct
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, src)
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		dir    string
+		errMsg string
+	}{
+		{
+			name:   "not a structure",
+			dir:    "testdata/src/not_struct",
+			errMsg: "annotated type must be a structure",
+		},
+		{
+			name:   "unknown directive argument",
+			dir:    "testdata/src/unknown_arg",
+			errMsg: "unknown directive argument",
+		},
+		{
+			name:   "missing package",
+			dir:    "testdata/src/missing",
+			errMsg: "load package",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := gen.Generate(tt.dir, gen.DefaultOutput, structure.Tags{}) //nolint:exhaustruct
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Code generated by exhaustruct gen. DO NOT EDIT.
+
+package basic
+
+import (
+	"net/http"
+	"time"
+)
+
+// NewClient creates Client with all required fields initialized.
+func NewClient(baseURL string, http *http.Client, id int, type_ string) Client {
+	return Client{
+		BaseURL: baseURL,
+		HTTP:    http,
+		ID:      id,
+		Type:    type_,
+	}
+}
+
+// NewServer creates Server with all required fields initialized.
+func NewServer(addr string, handler http.Handler, opts ...ServerOption) Server {
+	res := Server{
+		Addr:    addr,
+		Handler: handler,
+	}
+
+	for _, opt := range opts {
+		opt(&res)
+	}
+
+	return res
+}
+
+// ServerOption sets optional fields of Server.
+type ServerOption func(*Server)
+
+// WithServerReadTimeout sets optional field ReadTimeout of Server.
+func WithServerReadTimeout(readTimeout time.Duration) ServerOption {
+	return func(v *Server) {
+		v.ReadTimeout = readTimeout
+	}
+}
+
+// WithServerLogger sets optional field Logger of Server.
+func WithServerLogger(logger func(string)) ServerOption {
+	return func(v *Server) {
+		v.Logger = logger
+	}
+}
+
+// newPair creates pair with all required fields initialized.
+func newPair[K comparable, V any](key K, value V, opts ...pairOption[K, V]) pair[K, V] {
+	res := pair[K, V]{
+		key:   key,
+		value: value,
+	}
+
+	for _, opt := range opts {
+		opt(&res)
+	}
+
+	return res
+}
+
+// pairOption sets optional fields of pair.
+type pairOption[K comparable, V any] func(*pair[K, V])
+
+// withPairComment sets optional field comment of pair.
+func withPairComment[K comparable, V any](comment string) pairOption[K, V] {
+	return func(v *pair[K, V]) {
+		v.comment = comment
+	}
+}
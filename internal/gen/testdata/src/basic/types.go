@@ -0,0 +1,42 @@
+package basic
+
+import (
+	"net/http"
+	"time"
+)
+
+// Client is annotated with constructor generation.
+//
+//exhaustruct:gen
+type Client struct {
+	BaseURL string
+	HTTP    *http.Client
+	ID      int
+	Type    string
+
+	Timeout time.Duration `exhaustruct:"optional"`
+}
+
+//exhaustruct:gen options
+type Server struct {
+	Addr    string
+	Handler http.Handler
+
+	ReadTimeout time.Duration `exhaustruct:"optional"`
+	Logger      func(string)  `exhaustruct:"optional"`
+
+	// managed by the server itself
+	startedAt time.Time `exhaustruct:"forbidden"`
+}
+
+//exhaustruct:gen options
+type pair[K comparable, V any] struct {
+	key   K
+	value V
+
+	comment string `exhaustruct:"optional"`
+}
+
+type NotAnnotated struct {
+	A string
+}
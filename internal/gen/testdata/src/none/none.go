@@ -0,0 +1,5 @@
+package none
+
+type Test struct {
+	A string
+}
@@ -0,0 +1,4 @@
+package not_struct
+
+//exhaustruct:gen
+type ID int
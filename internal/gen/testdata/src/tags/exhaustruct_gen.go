@@ -0,0 +1,41 @@
+// Code generated by exhaustruct gen. DO NOT EDIT.
+
+package tags
+
+// NewConfig creates Config with all required fields initialized.
+func NewConfig(name string, token string, opts ...ConfigOption) Config {
+	res := Config{
+		Name:  name,
+		Token: token,
+	}
+
+	for _, opt := range opts {
+		opt(&res)
+	}
+
+	return res
+}
+
+// ConfigOption sets optional fields of Config.
+type ConfigOption func(*Config)
+
+// WithConfigComment sets optional field Comment of Config.
+func WithConfigComment(comment string) ConfigOption {
+	return func(v *Config) {
+		v.Comment = comment
+	}
+}
+
+// WithConfigPort sets optional field Port of Config.
+func WithConfigPort(port int) ConfigOption {
+	return func(v *Config) {
+		v.Port = port
+	}
+}
+
+// WithConfigOwner sets optional field Owner of Config.
+func WithConfigOwner(owner string) ConfigOption {
+	return func(v *Config) {
+		v.Owner = owner
+	}
+}
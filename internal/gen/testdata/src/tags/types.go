@@ -0,0 +1,10 @@
+package tags
+
+//exhaustruct:gen options
+type Config struct {
+	Name    string `json:"name"`
+	Comment string `json:"comment,omitempty"`
+	Port    int    `default:"8080"`
+	Token   string `json:"token,omitempty" validate:"required"`
+	Owner   string `lint:"optional"`
+}
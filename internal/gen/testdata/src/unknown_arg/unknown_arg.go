@@ -0,0 +1,6 @@
+package unknown_arg
+
+//exhaustruct:gen builder
+type Test struct {
+	A string
+}
@@ -6,10 +6,20 @@ import (
 )
 
 type FieldsCache struct {
+	tags   Tags
 	fields map[*types.Struct]Fields
 	mu     sync.RWMutex
 }
 
+// NewFieldsCache creates a cache of struct fields, interpreting field tags
+// according to given tags configuration.
+func NewFieldsCache(tags Tags) *FieldsCache {
+	return &FieldsCache{
+		tags:   tags,
+		fields: make(map[*types.Struct]Fields),
+	}
+}
+
 // Get returns a struct fields for a given type. In case if a struct fields is
 // not found, it creates a new one from type definition.
 func (c *FieldsCache) Get(typ *types.Struct) Fields {
@@ -28,7 +38,7 @@ func (c *FieldsCache) Get(typ *types.Struct) Fields {
 		c.fields = make(map[*types.Struct]Fields)
 	}
 
-	fields = NewFields(typ)
+	fields = NewFields(typ, c.tags)
 	c.fields[typ] = fields
 
 	return fields
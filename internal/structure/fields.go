@@ -3,45 +3,56 @@ package structure
 import (
 	"go/ast"
 	"go/types"
-	"reflect"
 	"strings"
 )
 
 const (
-	tagName          = "exhaustruct"
-	optionalTagValue = "optional"
+	optionalTagValue  = "optional"
+	nonZeroTagValue   = "nonzero"
+	forbiddenTagValue = "forbidden"
 )
 
 type Field struct {
 	Name     string
 	Exported bool
 	Optional bool
+
+	// Embedded is true for embedded fields, which are named after their type.
+	Embedded bool
+
+	// NonZero is true for fields marked with `exhaustruct:"nonzero"` tag,
+	// which must not be set to constant zero value.
+	NonZero bool
+
+	// Forbidden is true for fields marked with `exhaustruct:"forbidden"` tag,
+	// which must not be set in literals at all.
+	Forbidden bool
 }
 
 type Fields []*Field
 
-// NewFields creates a new [Fields] from a given struct type.
-// Fields items are listed in order they appear in the struct.
-func NewFields(strct *types.Struct) Fields {
+// NewFields creates a new [Fields] from a given struct type, interpreting field
+// tags according to given tags configuration. Fields items are listed in order
+// they appear in the struct.
+func NewFields(strct *types.Struct, tags Tags) Fields {
 	sf := make(Fields, 0, strct.NumFields())
 
 	for i := 0; i < strct.NumFields(); i++ {
 		f := strct.Field(i)
 
 		sf = append(sf, &Field{
-			Name:     f.Name(),
-			Exported: f.Exported(),
-			Optional: HasOptionalTag(strct.Tag(i)),
+			Name:      f.Name(),
+			Exported:  f.Exported(),
+			Optional:  tags.IsOptional(strct.Tag(i)),
+			Embedded:  f.Embedded(),
+			NonZero:   tags.IsNonZero(strct.Tag(i)),
+			Forbidden: tags.IsForbidden(strct.Tag(i)),
 		})
 	}
 
 	return sf
 }
 
-func HasOptionalTag(tags string) bool {
-	return reflect.StructTag(tags).Get(tagName) == optionalTagValue
-}
-
 // String returns a comma-separated list of field names.
 func (sf Fields) String() string {
 	b := strings.Builder{}
@@ -88,7 +99,7 @@ func (sf Fields) Skipped(lit *ast.CompositeLit, onlyExported bool) Fields {
 	}
 
 	for i := 0; i < len(sf); i++ {
-		if em[sf[i].Name] || (!sf[i].Exported && onlyExported) || sf[i].Optional {
+		if em[sf[i].Name] || (!sf[i].Exported && onlyExported) || sf[i].Optional || sf[i].Forbidden {
 			continue
 		}
 
@@ -102,6 +113,38 @@ func (sf Fields) Skipped(lit *ast.CompositeLit, onlyExported bool) Fields {
 	return res
 }
 
+// Has checks whether the list contains a field with a given name.
+func (sf Fields) Has(name string) bool {
+	return sf.Get(name) != nil
+}
+
+// Get returns a field with a given name, or nil if there is no such field.
+func (sf Fields) Get(name string) *Field {
+	for i := 0; i < len(sf); i++ {
+		if sf[i].Name == name {
+			return sf[i]
+		}
+	}
+
+	return nil
+}
+
+// Required returns a list of fields that are expected to be present in a
+// literal.
+func (sf Fields) Required(onlyExported bool) Fields {
+	res := make(Fields, 0, len(sf))
+
+	for i := 0; i < len(sf); i++ {
+		if (!sf[i].Exported && onlyExported) || sf[i].Optional || sf[i].Forbidden {
+			continue
+		}
+
+		res = append(res, sf[i])
+	}
+
+	return res
+}
+
 func (sf Fields) existenceMap() map[string]bool {
 	m := make(map[string]bool, len(sf))
 
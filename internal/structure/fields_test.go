@@ -5,20 +5,12 @@ import (
 	"go/types"
 	"testing"
 
-	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/tools/go/packages"
 
 	"dev.gaijin.team/go/exhaustruct/v4/internal/structure"
 )
 
-func Test_HasOptionalTag(t *testing.T) {
-	t.Parallel()
-
-	assert.True(t, structure.HasOptionalTag(`exhaustruct:"optional"`))
-	assert.False(t, structure.HasOptionalTag(`exhaustruct:"required"`))
-}
-
 func TestStructFields(t *testing.T) {
 	t.Parallel()
 
@@ -36,7 +28,7 @@ func (s *StructFieldsSuite) SetupSuite() {
 	pkgs, err := packages.Load(&packages.Config{ //nolint:exhaustruct
 		Mode: packages.NeedTypes | packages.NeedTypesInfo | packages.NeedTypesSizes | packages.NeedSyntax,
 		Dir:  "testdata",
-	}, "")
+	}, ".")
 	s.Require().NoError(err)
 	s.Require().Len(pkgs, 1)
 
@@ -56,7 +48,7 @@ func (s *StructFieldsSuite) getReferenceStructFields() structure.Fields {
 	typ := s.pkg.TypesInfo.TypeOf(obj.Decl.(*ast.TypeSpec).Type) //nolint:forcetypeassert
 	s.Require().NotNil(typ)
 
-	return structure.NewFields(typ.Underlying().(*types.Struct)) //nolint:forcetypeassert
+	return structure.NewFields(typ.Underlying().(*types.Struct), structure.Tags{}) //nolint:forcetypeassert,exhaustruct
 }
 
 func (s *StructFieldsSuite) TestNewStructFields() {
@@ -65,28 +57,71 @@ func (s *StructFieldsSuite) TestNewStructFields() {
 	s.Assert().Len(sf, 4)
 	s.Assert().Equal(structure.Fields{
 		{
-			Name:     "ExportedRequired",
-			Exported: true,
-			Optional: false,
+			Name:      "ExportedRequired",
+			Exported:  true,
+			Optional:  false,
+			Embedded:  false,
+			NonZero:   false,
+			Forbidden: false,
 		},
 		{
-			Name:     "unexportedRequired",
-			Exported: false,
-			Optional: false,
+			Name:      "unexportedRequired",
+			Exported:  false,
+			Optional:  false,
+			Embedded:  false,
+			NonZero:   false,
+			Forbidden: false,
 		},
 		{
-			Name:     "ExportedOptional",
-			Exported: true,
-			Optional: true,
+			Name:      "ExportedOptional",
+			Exported:  true,
+			Optional:  true,
+			Embedded:  false,
+			NonZero:   false,
+			Forbidden: false,
 		},
 		{
-			Name:     "unexportedOptional",
-			Exported: false,
-			Optional: true,
+			Name:      "unexportedOptional",
+			Exported:  false,
+			Optional:  true,
+			Embedded:  false,
+			NonZero:   false,
+			Forbidden: false,
 		},
 	}, sf)
 }
 
+func (s *StructFieldsSuite) TestNewStructFields_Tags() {
+	obj := s.scope.Lookup("taggedStruct")
+	s.Require().NotNil(obj)
+
+	typ := s.pkg.TypesInfo.TypeOf(obj.Decl.(*ast.TypeSpec).Type) //nolint:forcetypeassert
+	s.Require().NotNil(typ)
+
+	sf := structure.NewFields(typ.Underlying().(*types.Struct), structure.Tags{ //nolint:forcetypeassert
+		Key:      "custom",
+		Optional: []structure.TagRule{{Key: "json", Value: "omitempty", Unnamed: false}, {Key: "default", Value: "", Unnamed: false}},
+		Required: []structure.TagRule{{Key: "validate", Value: "required", Unnamed: true}},
+	})
+
+	optional := make(map[string]bool)
+	for _, f := range sf {
+		optional[f.Name] = f.Optional
+	}
+
+	s.Assert().Equal(map[string]bool{
+		"Name":      false,
+		"Comment":   true,
+		"Port":      true,
+		"ID":        false,
+		"Owner":     true,
+		"Handler":   false,
+		"Legacy":    false,
+		"Omitempty": false,
+	}, optional)
+	s.Assert().True(sf.Get("Handler").NonZero)
+}
+
 func (s *StructFieldsSuite) TestStructFields_String() {
 	sf := s.getReferenceStructFields()
 
@@ -96,6 +131,18 @@ func (s *StructFieldsSuite) TestStructFields_String() {
 	)
 }
 
+func (s *StructFieldsSuite) TestStructFields_Required() {
+	sf := s.getReferenceStructFields()
+
+	s.Assert().Equal(structure.Fields{
+		{"ExportedRequired", true, false, false, false, false},
+	}, sf.Required(true))
+	s.Assert().Equal(structure.Fields{
+		{"ExportedRequired", true, false, false, false, false},
+		{"unexportedRequired", false, false, false, false, false},
+	}, sf.Required(false))
+}
+
 func (s *StructFieldsSuite) TestStructFields_SkippedFields_Unnamed() {
 	sf := s.getReferenceStructFields()
 
@@ -113,9 +160,9 @@ func (s *StructFieldsSuite) TestStructFields_SkippedFields_Unnamed() {
 		lit := unnamedIncomplete.Decl.(*ast.ValueSpec).Values[0].(*ast.CompositeLit) //nolint:forcetypeassert
 		if s.Assert().NotNil(lit) {
 			s.Assert().Equal(structure.Fields{
-				{"unexportedRequired", false, false},
-				{"ExportedOptional", true, true},
-				{"unexportedOptional", false, true},
+				{"unexportedRequired", false, false, false, false, false},
+				{"ExportedOptional", true, true, false, false, false},
+				{"unexportedOptional", false, true, false, false, false},
 			}, sf.Skipped(lit, true))
 		}
 	}
@@ -139,7 +186,7 @@ func (s *StructFieldsSuite) TestStructFields_SkippedFields_Named() {
 		if s.Assert().NotNil(lit) {
 			s.Assert().Nil(sf.Skipped(lit, true))
 			s.Assert().Equal(structure.Fields{
-				{"unexportedRequired", false, false},
+				{"unexportedRequired", false, false, false, false, false},
 			}, sf.Skipped(lit, false))
 		}
 	}
@@ -149,11 +196,11 @@ func (s *StructFieldsSuite) TestStructFields_SkippedFields_Named() {
 		lit := namedIncomplete2.Decl.(*ast.ValueSpec).Values[0].(*ast.CompositeLit) //nolint:forcetypeassert
 		if s.Assert().NotNil(lit) {
 			s.Assert().Equal(structure.Fields{
-				{"ExportedRequired", true, false},
+				{"ExportedRequired", true, false, false, false, false},
 			}, sf.Skipped(lit, true))
 			s.Assert().Equal(structure.Fields{
-				{"ExportedRequired", true, false},
-				{"unexportedRequired", false, false},
+				{"ExportedRequired", true, false, false, false, false},
+				{"unexportedRequired", false, false, false, false, false},
 			}, sf.Skipped(lit, false))
 		}
 	}
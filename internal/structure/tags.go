@@ -0,0 +1,164 @@
+package structure
+
+import (
+	"reflect"
+	"strings"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+)
+
+// DefaultTagKey is a default key of field tags holding exhaustruct
+// annotations.
+const DefaultTagKey = "exhaustruct"
+
+// TagRule matches field tags of other libraries, e.g. `json:"name,omitempty"`
+// or `validate:"required"`.
+type TagRule struct {
+	// Key is a tag key, e.g. `json`.
+	Key string
+
+	// Value is one of comma-separated tag options following the name part,
+	// e.g. `omitempty` matches `json:"name,omitempty"`, but not
+	// `json:"omitempty"`. Empty value matches any tag with given key, e.g.
+	// `default:"x"`.
+	Value string
+
+	// Unnamed tells that tag has no name part, so Value is matched against
+	// all comma-separated elements, e.g. `required` matches
+	// `validate:"required,min=1"`.
+	Unnamed bool
+}
+
+// ParseTagRule parses tag rule from string in form of `<key>[:<value>]` or
+// `<key>=<value>`, e.g. `json:omitempty`, `validate=required` or `default`.
+// The former form skips the name part of the tag, the latter does not.
+func ParseTagRule(s string) (TagRule, error) {
+	sep := strings.IndexAny(s, ":=")
+	if sep < 0 {
+		sep = len(s)
+	}
+
+	key := s[:sep]
+	if key == "" || strings.ContainsAny(key, " \t\"") {
+		return TagRule{}, e.New("tag rule must be in form of <key>[:<value>] or <key>=<value>",
+			fields.F("tag-rule", s))
+	}
+
+	if sep == len(s) {
+		return TagRule{Key: key, Value: "", Unnamed: false}, nil
+	}
+
+	return TagRule{Key: key, Value: s[sep+1:], Unnamed: s[sep] == '='}, nil
+}
+
+// String returns tag rule in the same form it is parsed by [ParseTagRule].
+func (r TagRule) String() string {
+	switch {
+	case r.Value == "":
+		return r.Key
+	case r.Unnamed:
+		return r.Key + "=" + r.Value
+	default:
+		return r.Key + ":" + r.Value
+	}
+}
+
+// matches checks whether field tags match the rule.
+func (r TagRule) matches(tags string) bool {
+	v, ok := reflect.StructTag(tags).Lookup(r.Key)
+	if !ok {
+		return false
+	}
+
+	if r.Value == "" {
+		return true
+	}
+
+	if r.Unnamed {
+		return hasValue(v, r.Value)
+	}
+
+	// the first element is a name, e.g. `json:"omitempty"` names field
+	// "omitempty", but does not make it omitted
+	_, opts, ok := strings.Cut(v, ",")
+
+	return ok && hasValue(opts, r.Value)
+}
+
+// Tags defines how field tags are interpreted. Zero value interprets only
+// `exhaustruct` tag.
+type Tags struct {
+	// Key is a key of tags holding exhaustruct annotations, e.g.
+	// `exhaustruct:"optional"`. Empty value is treated as [DefaultTagKey].
+	Key string
+
+	// Optional is a list of rules marking fields optional, e.g. `default`.
+	Optional []TagRule
+
+	// Required is a list of rules marking fields required, e.g.
+	// `validate=required`. Has precedence over Optional, but not over
+	// exhaustruct own annotations.
+	Required []TagRule
+}
+
+// key returns a key of tags holding exhaustruct annotations.
+func (t Tags) key() string {
+	if t.Key == "" {
+		return DefaultTagKey
+	}
+
+	return t.Key
+}
+
+// has checks whether exhaustruct annotation of field tags holds a given value.
+func (t Tags) has(tags, value string) bool {
+	return hasValue(reflect.StructTag(tags).Get(t.key()), value)
+}
+
+// IsOptional checks whether field tags mark field as optional, either by
+// exhaustruct annotation or by tags of other libraries. Annotation may hold
+// several comma-separated values, e.g. `exhaustruct:"optional,nonzero"`.
+func (t Tags) IsOptional(tags string) bool {
+	if t.has(tags, optionalTagValue) {
+		return true
+	}
+
+	for _, r := range t.Required {
+		if r.matches(tags) {
+			return false
+		}
+	}
+
+	for _, r := range t.Optional {
+		if r.matches(tags) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsNonZero checks whether field tags mark field as non-zero by exhaustruct
+// annotation.
+func (t Tags) IsNonZero(tags string) bool {
+	return t.has(tags, nonZeroTagValue)
+}
+
+// IsForbidden checks whether field tags mark field as forbidden by
+// exhaustruct annotation.
+func (t Tags) IsForbidden(tags string) bool {
+	return t.has(tags, forbiddenTagValue)
+}
+
+// hasValue checks whether a comma-separated list of tag values contains a
+// given value.
+func hasValue(values, value string) bool {
+	for _, v := range strings.Split(values, ",") {
+		if strings.TrimSpace(v) == value {
+			return true
+		}
+	}
+
+	return false
+}
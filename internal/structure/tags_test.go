@@ -0,0 +1,87 @@
+package structure_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/exhaustruct/v4/internal/structure"
+)
+
+func TestParseTagRule(t *testing.T) {
+	t.Parallel()
+
+	r, err := structure.ParseTagRule("json:omitempty")
+	require.NoError(t, err)
+	assert.Equal(t, structure.TagRule{Key: "json", Value: "omitempty", Unnamed: false}, r)
+	assert.Equal(t, "json:omitempty", r.String())
+
+	r, err = structure.ParseTagRule("default")
+	require.NoError(t, err)
+	assert.Equal(t, structure.TagRule{Key: "default", Value: "", Unnamed: false}, r)
+	assert.Equal(t, "default", r.String())
+
+	r, err = structure.ParseTagRule("validate=required")
+	require.NoError(t, err)
+	assert.Equal(t, structure.TagRule{Key: "validate", Value: "required", Unnamed: true}, r)
+	assert.Equal(t, "validate=required", r.String())
+
+	for _, s := range []string{"", ":omitempty", "=required", `json":omitempty`} {
+		_, err = structure.ParseTagRule(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestTags_IsOptional(t *testing.T) {
+	t.Parallel()
+
+	tags := structure.Tags{} //nolint:exhaustruct
+
+	assert.True(t, tags.IsOptional(`exhaustruct:"optional"`))
+	assert.False(t, tags.IsOptional(`exhaustruct:"required"`))
+	assert.True(t, tags.IsOptional(`exhaustruct:"optional,nonzero"`))
+	assert.False(t, tags.IsOptional(`json:"name,omitempty"`))
+
+	tags = structure.Tags{
+		Key:      "lint",
+		Optional: []structure.TagRule{{Key: "json", Value: "omitempty", Unnamed: false}},
+		Required: []structure.TagRule{{Key: "validate", Value: "required", Unnamed: true}},
+	}
+
+	assert.True(t, tags.IsOptional(`lint:"optional"`))
+	assert.False(t, tags.IsOptional(`exhaustruct:"optional"`))
+	assert.True(t, tags.IsOptional(`json:"name,omitempty"`))
+	assert.False(t, tags.IsOptional(`json:"omitempty"`))
+	assert.False(t, tags.IsOptional(`json:"name,omitempty" validate:"required"`))
+}
+
+func TestTags_IsNonZero(t *testing.T) {
+	t.Parallel()
+
+	tags := structure.Tags{} //nolint:exhaustruct
+
+	assert.True(t, tags.IsNonZero(`exhaustruct:"nonzero"`))
+	assert.True(t, tags.IsNonZero(`exhaustruct:"optional, nonzero"`))
+	assert.False(t, tags.IsNonZero(`exhaustruct:"optional"`))
+	assert.False(t, tags.IsNonZero(`json:"nonzero"`))
+
+	tags = structure.Tags{Key: "lint", Optional: nil, Required: nil}
+
+	assert.True(t, tags.IsNonZero(`lint:"nonzero"`))
+	assert.False(t, tags.IsNonZero(`exhaustruct:"nonzero"`))
+}
+
+func TestTags_IsForbidden(t *testing.T) {
+	t.Parallel()
+
+	tags := structure.Tags{} //nolint:exhaustruct
+
+	assert.True(t, tags.IsForbidden(`exhaustruct:"forbidden"`))
+	assert.False(t, tags.IsForbidden(`exhaustruct:"optional"`))
+
+	tags = structure.Tags{Key: "lint", Optional: nil, Required: nil}
+
+	assert.True(t, tags.IsForbidden(`lint:"forbidden"`))
+	assert.False(t, tags.IsForbidden(`exhaustruct:"forbidden"`))
+}
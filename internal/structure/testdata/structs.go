@@ -28,3 +28,16 @@ var (
 		unexportedOptional: 4,
 	}
 )
+
+type taggedStruct struct {
+	Name    string `json:"name"`
+	Comment string `json:"comment,omitempty"`
+	Port    int    `default:"80"`
+	ID      string `json:"id,omitempty" validate:"required"`
+	Owner   string `custom:"optional" validate:"required"`
+	Handler func() `custom:"nonzero"`
+	Legacy  string `exhaustruct:"optional"`
+
+	// field named "omitempty", but not omitted
+	Omitempty string `json:"omitempty"`
+}